@@ -0,0 +1,210 @@
+package mini_sql_db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/lexer"
+	"github.com/Vignesh-Rajarajan/go-db/parser"
+	"github.com/Vignesh-Rajarajan/go-db/planner"
+	"github.com/Vignesh-Rajarajan/go-db/sql"
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"time"
+)
+
+// ErrQueryCanceled is wrapped by the ExecutionError returned when the context of a statement is canceled
+// or its deadline, including the one set by Database.Timeout, is exceeded
+var ErrQueryCanceled = query.ErrQueryCanceled
+
+// Database runs SQL text against the tables of a storage.Database
+type Database struct {
+	Storage *storage.Database
+	// Timeout limits the time every statement may run for, zero means no limit
+	Timeout time.Duration
+}
+
+// NewDatabase returns a Database without any table
+func NewDatabase() *Database {
+	return Open(storage.NewDatabase())
+}
+
+// Open returns a Database running statements against existing storage, such as storage.GetSampleData
+func Open(db *storage.Database) *Database {
+	return &Database{Storage: db}
+}
+
+// Result is the outcome of a statement. Relation holds the rows returned by a SELECT or an EXPLAIN and is
+// nil for every other statement, which report the number of rows they inserted, updated or deleted instead.
+type Result struct {
+	Relation     *types.Relation
+	RowsAffected int
+}
+
+// SyntaxError is returned for statements that cannot be tokenized or parsed
+type SyntaxError struct {
+	// Position is the offset in the statement at which the error was found
+	Position int
+	Err      error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %v", e.Position, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// PlanError is returned for statements that are well-formed but invalid, for example because they
+// reference a missing table or column or compare values of different types
+type PlanError struct {
+	Err error
+}
+
+func (e *PlanError) Error() string {
+	return fmt.Sprintf("planning error: %v", e.Err)
+}
+
+func (e *PlanError) Unwrap() error {
+	return e.Err
+}
+
+// ExecutionError is returned when a planned statement fails while running, for example when a
+// row violates a NOT NULL constraint or a division by zero is evaluated
+type ExecutionError struct {
+	Err error
+}
+
+func (e *ExecutionError) Error() string {
+	return fmt.Sprintf("execution error: %v", e.Err)
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Exec runs a single statement of any kind
+func (db *Database) Exec(statement string) (*Result, error) {
+	return db.ExecContext(context.Background(), statement)
+}
+
+// ExecContext runs a single statement of any kind, stopping it with an error wrapping ErrQueryCanceled once ctx is done
+func (db *Database) ExecContext(ctx context.Context, statement string) (*Result, error) {
+	stmt, err := parse(statement)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	switch stmt.(type) {
+	case *sql.SelectStatement, *sql.ExplainStatement:
+		relation, err := db.query(ctx, stmt)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{Relation: relation, RowsAffected: len(relation.Rows)}, nil
+	}
+
+	command, err := planner.PlanCommand(stmt, db.Storage)
+	if err != nil {
+		return nil, &PlanError{Err: err}
+	}
+	count, err := recoverPanic(func() (int, error) {
+		// commands that do not read rows have no other point at which they check for cancellation
+		if err := ctx.Err(); err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrQueryCanceled, err)
+		}
+		return command.Execute(ctx, db.Storage)
+	})
+	if err != nil {
+		return nil, &ExecutionError{Err: err}
+	}
+	return &Result{RowsAffected: count}, nil
+}
+
+// Query runs a SELECT statement and returns its rows, or the plan of an EXPLAIN statement
+func (db *Database) Query(statement string) (*types.Relation, error) {
+	return db.QueryContext(context.Background(), statement)
+}
+
+// QueryContext runs a SELECT or EXPLAIN statement and returns its rows, stopping it with an error wrapping ErrQueryCanceled once ctx is done
+func (db *Database) QueryContext(ctx context.Context, statement string) (*types.Relation, error) {
+	stmt, err := parse(statement)
+	if err != nil {
+		return nil, err
+	}
+	switch stmt.(type) {
+	case *sql.SelectStatement, *sql.ExplainStatement:
+	default:
+		return nil, &PlanError{Err: fmt.Errorf("Query requires a SELECT or EXPLAIN statement, use Exec to run %T", stmt)}
+	}
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	return db.query(ctx, stmt)
+}
+
+func (db *Database) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.Timeout > 0 {
+		return context.WithTimeout(ctx, db.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// query runs a statement returning rows, either a SELECT or an EXPLAIN
+func (db *Database) query(ctx context.Context, stmt sql.Statement) (*types.Relation, error) {
+	var plan query.QueryPlan
+	var err error
+	if explain, ok := stmt.(*sql.ExplainStatement); ok {
+		plan, err = planner.PlanExplain(explain, db.Storage)
+	} else {
+		plan, err = planner.Plan(stmt.(*sql.SelectStatement), db.Storage)
+	}
+	if err != nil {
+		return nil, &PlanError{Err: err}
+	}
+	var relation *types.Relation
+	_, err = recoverPanic(func() (int, error) {
+		relation, err = plan.Run(ctx, db.Storage)
+		if err != nil {
+			return 0, err
+		}
+		return len(relation.Rows), nil
+	})
+	if err != nil {
+		return nil, &ExecutionError{Err: err}
+	}
+	return relation, nil
+}
+
+func parse(statement string) (sql.Statement, error) {
+	stmt, err := parser.Parse(statement)
+	if err != nil {
+		syntaxError := &SyntaxError{Err: err}
+		var value lexer.SyntaxError
+		var pointer *lexer.SyntaxError
+		if errors.As(err, &value) {
+			syntaxError.Position = value.Position
+		} else if errors.As(err, &pointer) {
+			syntaxError.Position = pointer.Position
+		}
+		return nil, syntaxError
+	}
+	return stmt, nil
+}
+
+// recoverPanic runs f, turning a panic into an error. Runtime errors are returned by the plans,
+// so this only guards the host process against bugs in an operator.
+func recoverPanic(f func() (int, error)) (count int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	return f()
+}
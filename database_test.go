@@ -0,0 +1,264 @@
+package mini_sql_db
+
+import (
+	"context"
+	"errors"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDatabaseExec(t *testing.T) {
+	db := NewDatabase()
+
+	statements := []struct {
+		statement string
+		want      int
+	}{
+		{statement: "CREATE TABLE t (id integer NOT NULL, name text)", want: 0},
+		{statement: "INSERT INTO t VALUES (1, 'a'), (2, 'b'), (3, NULL)", want: 3},
+		{statement: "UPDATE t SET name = 'c' WHERE name IS NULL", want: 1},
+		{statement: "DELETE FROM t WHERE id = 1", want: 1},
+		{statement: "SELECT * FROM t;", want: 2},
+	}
+	for _, s := range statements {
+		result, err := db.Exec(s.statement)
+		if err != nil {
+			t.Fatalf("Exec(%q) unexpected error: %v", s.statement, err)
+		}
+		if result.RowsAffected != s.want {
+			t.Fatalf("Exec(%q) = %d affected rows, want %d", s.statement, result.RowsAffected, s.want)
+		}
+	}
+
+	got, err := db.Query("SELECT name FROM t ORDER BY id DESC")
+	if err != nil {
+		t.Fatalf("Query unexpected error: %v", err)
+	}
+	want := [][]types.Value{{types.NewText("c")}, {types.NewText("b")}}
+	if !reflect.DeepEqual(got.Rows, want) {
+		t.Fatalf("got %v, want %v", got.Rows, want)
+	}
+}
+
+func TestDatabaseErrors(t *testing.T) {
+	db := Open(storage.GetSampleData().Database)
+
+	cases := []struct {
+		statement string
+		want      any
+	}{
+		{statement: "SELECT FROM WHERE", want: new(*SyntaxError)},
+		{statement: "SELECT * FROM films #", want: new(*SyntaxError)},
+		{statement: "SELECT * FROM missing", want: new(*PlanError)},
+		{statement: "INSERT INTO people VALUES ('x', 1)", want: new(*PlanError)},
+		{statement: "INSERT INTO people (id, name) VALUES (NULL, 'x')", want: new(*ExecutionError)},
+		{statement: "SELECT id / 0 FROM films", want: new(*ExecutionError)},
+	}
+	for _, c := range cases {
+		t.Run(c.statement, func(t *testing.T) {
+			_, err := db.Exec(c.statement)
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !errors.As(err, c.want) {
+				t.Fatalf("got %T: %v, want %T", err, err, c.want)
+			}
+		})
+	}
+
+	if _, err := db.Query("DELETE FROM films"); err == nil {
+		t.Fatalf("Query of a DELETE: expected error, got nil")
+	}
+	if relation, err := db.Query("SELECT * FROM films"); err != nil || len(relation.Rows) != 3 {
+		t.Fatalf("Query must not have run the DELETE: %v, %v", relation, err)
+	}
+}
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	_, err := NewDatabase().Exec("SELECT * FROM foo WHERE")
+	var syntaxError *SyntaxError
+	if !errors.As(err, &syntaxError) {
+		t.Fatalf("got %T: %v, want *SyntaxError", err, err)
+	}
+	if syntaxError.Position != 23 {
+		t.Fatalf("got position %d, want 23", syntaxError.Position)
+	}
+}
+
+func TestDatabaseCancel(t *testing.T) {
+	db := Open(storage.GetSampleData().Database)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, statement := range []string{
+		"SELECT * FROM films JOIN people ON films.id > people.id",
+		"UPDATE films SET title = 'x'",
+		"CREATE TABLE t (id integer)",
+	} {
+		_, err := db.ExecContext(ctx, statement)
+		var executionError *ExecutionError
+		if !errors.As(err, &executionError) || !errors.Is(err, ErrQueryCanceled) || !errors.Is(err, context.Canceled) {
+			t.Errorf("ExecContext(%q) = %v, want an ExecutionError wrapping ErrQueryCanceled", statement, err)
+		}
+	}
+	if relation, err := db.Query("SELECT * FROM films WHERE title = 'x'"); err != nil || len(relation.Rows) != 0 {
+		t.Errorf("canceled UPDATE changed rows: %v, %v", relation, err)
+	}
+	if _, err := db.Storage.GetTable("t"); err == nil {
+		t.Errorf("canceled CREATE TABLE created the table")
+	}
+}
+
+func TestDatabaseTimeout(t *testing.T) {
+	db := Open(storage.GetSampleData().Database)
+	db.Timeout = time.Nanosecond
+	_, err := db.QueryContext(context.Background(), "SELECT * FROM films JOIN people ON films.id > people.id")
+	if !errors.Is(err, ErrQueryCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("QueryContext() = %v, want ErrQueryCanceled once the timeout expired", err)
+	}
+
+	db.Timeout = time.Minute
+	if _, err := db.Query("SELECT * FROM films"); err != nil {
+		t.Errorf("Query() = %v, want nil within the timeout", err)
+	}
+}
+
+func TestDatabaseExplain(t *testing.T) {
+	db := Open(storage.GetSampleData().Database)
+
+	result, err := db.Exec("EXPLAIN SELECT title FROM films WHERE id = 1")
+	if err != nil {
+		t.Fatalf("Exec() unexpected error: %v", err)
+	}
+	var lines []string
+	for _, row := range result.Relation.Rows {
+		lines = append(lines, row[0].(types.Text).Value())
+	}
+	if len(lines) == 0 || lines[0] != "Project {" || strings.Contains(strings.Join(lines, "\n"), "Actual:") {
+		t.Errorf("EXPLAIN = %q, want the plan without statistics", lines)
+	}
+
+	relation, err := db.Query("EXPLAIN ANALYZE SELECT title FROM films WHERE id = 1")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	want := []string{"Project {", "Actual: rows=1 loops=1", "Select {", "Actual: rows=1 loops=1", "Load {", "Actual: rows=3 loops=1"}
+	// only the nodes and their row counts are stable between runs
+	var got []string
+	for _, row := range relation.Rows {
+		line := strings.TrimSpace(row[0].(types.Text).Value())
+		if strings.HasSuffix(line, "{") || strings.HasPrefix(line, "Actual:") {
+			line, _, _ = strings.Cut(line, " time=")
+			got = append(got, line)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EXPLAIN ANALYZE nodes = %q, want %q", got, want)
+	}
+
+	if _, err := db.Exec("EXPLAIN ANALYZE SELECT id / 0 FROM films"); !errors.As(err, new(*ExecutionError)) {
+		t.Errorf("EXPLAIN ANALYZE of a failing query = %v, want an ExecutionError", err)
+	}
+}
+
+func TestDatabaseContradiction(t *testing.T) {
+	db := Open(storage.GetSampleData().Database)
+
+	relation, err := db.Query("SELECT title FROM films WHERE id > 1 AND 2 < 1")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	if len(relation.Rows) != 0 || len(relation.Schema.Columns) != 1 || relation.Schema.Columns[0].Name != "films.title" {
+		t.Errorf("Query() = %v, want no rows with a title column", relation)
+	}
+
+	// the table is never read
+	explained, err := db.Query("EXPLAIN ANALYZE SELECT title FROM films WHERE id > 1 AND 2 < 1")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	for _, row := range explained.Rows {
+		if line := row[0].(types.Text).Value(); strings.Contains(line, "Load") {
+			t.Errorf("EXPLAIN ANALYZE = %v, want a plan without Load", explained.Rows)
+			break
+		}
+	}
+}
+
+func TestDatabaseAnalyze(t *testing.T) {
+	db := Open(storage.GetSampleData().Database)
+
+	if _, err := db.Exec("ANALYZE"); err != nil {
+		t.Fatalf("Exec() unexpected error: %v", err)
+	}
+	relation, err := db.Query("SELECT table_name, row_count FROM table_statistics")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	want := [][]types.Value{
+		{types.NewText("films"), types.NewDecimal("3")},
+		{types.NewText("people"), types.NewDecimal("2")},
+	}
+	if !reflect.DeepEqual(relation.Rows, want) {
+		t.Errorf("table_statistics = %v, want %v", relation.Rows, want)
+	}
+
+	relation, err = db.Query("SELECT column_name, distinct_count, min_value, max_value FROM column_statistics WHERE table_name = 'films' AND column_name <> 'id'")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	// values are written as in a statement, so they compare with the values of the columns
+	want = [][]types.Value{
+		{types.NewText("title"), types.NewDecimal("3"), types.NewText("The Dark Knight"), types.NewText("The Shawshank Redemption")},
+		{types.NewText("director"), types.NewDecimal("2"), types.NewText("1"), types.NewText("2")},
+		{types.NewText("release_date"), types.NewDecimal("3"), types.NewText("1972-03-24"), types.NewText("2008-07-18")},
+	}
+	if !reflect.DeepEqual(relation.Rows, want) {
+		t.Errorf("column_statistics = %v, want %v", relation.Rows, want)
+	}
+
+	relation, err = db.Query("SELECT lower_bound, row_count FROM column_histograms WHERE table_name = 'films' AND column_name = 'director' ORDER BY bucket")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	want = [][]types.Value{{types.NewText("1"), types.NewDecimal("2")}, {types.NewText("2"), types.NewDecimal("1")}}
+	if !reflect.DeepEqual(relation.Rows, want) {
+		t.Errorf("column_histograms = %v, want %v", relation.Rows, want)
+	}
+
+	relation, err = db.Query("SELECT title FROM films JOIN column_statistics ON title = min_value WHERE release_date > '2000-01-01'")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	if want := [][]types.Value{{types.NewText("The Dark Knight")}}; !reflect.DeepEqual(relation.Rows, want) {
+		t.Errorf("films matching the smallest title = %v, want %v", relation.Rows, want)
+	}
+
+	// ALTER TABLE drops the statistics of the table until it is analyzed again
+	if _, err := db.Exec("ALTER TABLE films DROP COLUMN release_date"); err != nil {
+		t.Fatalf("Exec() unexpected error: %v", err)
+	}
+	relation, err = db.Query("SELECT table_name FROM table_statistics")
+	if err != nil {
+		t.Fatalf("Query() unexpected error: %v", err)
+	}
+	if want := [][]types.Value{{types.NewText("people")}}; !reflect.DeepEqual(relation.Rows, want) {
+		t.Errorf("table_statistics after ALTER TABLE = %v, want %v", relation.Rows, want)
+	}
+
+	for _, statement := range []string{
+		"ANALYZE missing",
+		"INSERT INTO table_statistics VALUES ('t', 1)",
+		"DELETE FROM column_statistics",
+		"DROP TABLE column_histograms",
+		"CREATE TABLE table_statistics (id integer)",
+	} {
+		if _, err := db.Exec(statement); err == nil {
+			t.Errorf("Exec(%q) = nil, want error", statement)
+		}
+	}
+}
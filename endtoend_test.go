@@ -1,6 +1,7 @@
 package mini_sql_db
 
 import (
+	"context"
 	"github.com/Vignesh-Rajarajan/go-db/parser"
 	"github.com/Vignesh-Rajarajan/go-db/planner"
 	"github.com/Vignesh-Rajarajan/go-db/sql"
@@ -10,6 +11,24 @@ import (
 	"testing"
 )
 
+func runQuery(t *testing.T, db *storage.Database, query string) *types.Relation {
+	t.Helper()
+	relation, err := Open(db).Query(query)
+	if err != nil {
+		t.Fatalf("Query unexpected error: %v", err)
+	}
+	return relation
+}
+
+func execute(t *testing.T, db *storage.Database, statement string) int {
+	t.Helper()
+	result, err := Open(db).Exec(statement)
+	if err != nil {
+		t.Fatalf("Exec unexpected error: %v", err)
+	}
+	return result.RowsAffected
+}
+
 func TestAll(t *testing.T) {
 	sampleData := storage.GetSampleData()
 	query := "SELECT films.title, people.name FROM films JOIN people ON films.director = people.id"
@@ -41,8 +60,569 @@ func TestAll(t *testing.T) {
 		t.Fatalf("planner.Plan unexpected error: %v", err)
 	}
 
-	got := plan.Run(sampleData.Database)
+	got, err := plan.Run(context.Background(), sampleData.Database)
+	if err != nil {
+		t.Fatalf("plan.Run unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestWhere(t *testing.T) {
+	titles := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "films.title", Type: types.TypeText},
+		},
+	}
+
+	cases := []struct {
+		query string
+		want  *types.Relation
+	}{
+		{
+			query: "SELECT title FROM films WHERE release_date > '1990-01-01'",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Shawshank Redemption")},
+					{types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE release_date <= '1994-09-23'",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Shawshank Redemption")},
+					{types.NewText("The Godfather")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE director = 2",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Godfather")},
+				},
+			},
+		},
+		{
+			query: "SELECT films.title FROM films JOIN people ON films.director = people.id WHERE people.name = 'Frank Darabont'",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Shawshank Redemption")},
+					{types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE director != 1",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Godfather")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE director = 1 AND release_date > '2000-01-01'",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE director = 2 OR release_date > '2000-01-01'",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Godfather")},
+					{types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE NOT (director = 2 OR release_date > '2000-01-01')",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Shawshank Redemption")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE id * 2 - director > 2",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE id / 3 = 1 OR id % 2 = 0",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Godfather")},
+					{types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE -director = 0 - 2",
+			want: &types.Relation{
+				Schema: titles,
+				Rows: [][]types.Value{
+					{types.NewText("The Godfather")},
+				},
+			},
+		},
+		{
+			query: "SELECT title FROM films WHERE director != director",
+			want: &types.Relation{
+				Schema: titles,
+				Rows:   [][]types.Value{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			sampleData := storage.GetSampleData()
+			got := runQuery(t, sampleData.Database, c.query)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestOuterJoin(t *testing.T) {
+	schema := func(namesNullable, titlesNullable bool) types.TableSchema {
+		return types.TableSchema{
+			Columns: []types.ColumnSchema{
+				{Name: "people.name", Type: types.TypeText, Nullable: namesNullable},
+				{Name: "films.title", Type: types.TypeText, Nullable: titlesNullable},
+			},
+		}
+	}
+
+	cases := []struct {
+		query string
+		want  *types.Relation
+	}{
+		{
+			query: "SELECT people.name, films.title FROM people LEFT JOIN films ON people.id = films.director AND films.release_date > '2000-01-01'",
+			want: &types.Relation{
+				Schema: schema(false, true),
+				Rows: [][]types.Value{
+					{types.NewText("Frank Darabont"), types.NewText("The Dark Knight")},
+					{types.NewText("Francis Ford Coppola"), types.NewNull(types.TypeText)},
+				},
+			},
+		},
+		{
+			query: "SELECT people.name, films.title FROM people RIGHT OUTER JOIN films ON people.id = films.director AND people.name = 'Frank Darabont'",
+			want: &types.Relation{
+				Schema: schema(true, false),
+				Rows: [][]types.Value{
+					{types.NewText("Frank Darabont"), types.NewText("The Shawshank Redemption")},
+					{types.NewNull(types.TypeText), types.NewText("The Godfather")},
+					{types.NewText("Frank Darabont"), types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT people.name, films.title FROM people LEFT JOIN films ON people.id = films.director AND films.release_date > '2000-01-01' WHERE films.title IS NULL",
+			want: &types.Relation{
+				Schema: schema(false, true),
+				Rows: [][]types.Value{
+					{types.NewText("Francis Ford Coppola"), types.NewNull(types.TypeText)},
+				},
+			},
+		},
+		{
+			query: "SELECT people.name, films.title FROM people LEFT JOIN films ON people.id = films.director AND films.release_date > '2000-01-01' WHERE films.title IS NOT NULL OR films.title = NULL",
+			want: &types.Relation{
+				Schema: schema(false, true),
+				Rows: [][]types.Value{
+					{types.NewText("Frank Darabont"), types.NewText("The Dark Knight")},
+				},
+			},
+		},
+		{
+			query: "SELECT people.name, films.title FROM people FULL JOIN films ON people.id = films.id AND films.director = 2",
+			want: &types.Relation{
+				Schema: schema(true, true),
+				Rows: [][]types.Value{
+					{types.NewNull(types.TypeText), types.NewText("The Shawshank Redemption")},
+					{types.NewText("Francis Ford Coppola"), types.NewText("The Godfather")},
+					{types.NewNull(types.TypeText), types.NewText("The Dark Knight")},
+					{types.NewText("Frank Darabont"), types.NewNull(types.TypeText)},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			sampleData := storage.GetSampleData()
+			got := runQuery(t, sampleData.Database, c.query)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	got := runQuery(t, sampleData.Database, "SELECT (id + 1) / 3 FROM films WHERE id < 3")
+	want := &types.Relation{
+		Schema: types.TableSchema{
+			Columns: []types.ColumnSchema{
+				{Name: "?column?1", Type: types.TypeDecimal, Nullable: true},
+			},
+		},
+		Rows: [][]types.Value{
+			{types.NewDecimal("0.6666666666666667")},
+			{types.NewDecimal("1")},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// several computed columns without an alias get distinct names
+	got = runQuery(t, sampleData.Database, "SELECT 10 % 3, -id, id AS i, id + 1 FROM films WHERE id = 2")
+	want = &types.Relation{
+		Schema: types.TableSchema{
+			Columns: []types.ColumnSchema{
+				{Name: "?column?1", Type: types.TypeDecimal},
+				{Name: "?column?2", Type: types.TypeDecimal, Nullable: true},
+				{Name: "i", Type: types.TypeDecimal},
+				{Name: "?column?4", Type: types.TypeDecimal, Nullable: true},
+			},
+		},
+		Rows: [][]types.Value{
+			{types.NewDecimal("1"), types.NewDecimal("-2"), types.NewDecimal("2"), types.NewDecimal("3")},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	titles := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "films.title", Type: types.TypeText},
+		},
+	}
+	godfather := []types.Value{types.NewText("The Godfather")}
+	shawshank := []types.Value{types.NewText("The Shawshank Redemption")}
+	darkKnight := []types.Value{types.NewText("The Dark Knight")}
+
+	cases := []struct {
+		query string
+		want  [][]types.Value
+	}{
+		{
+			query: "SELECT title FROM films ORDER BY release_date",
+			want:  [][]types.Value{godfather, shawshank, darkKnight},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY release_date DESC",
+			want:  [][]types.Value{darkKnight, shawshank, godfather},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY films.title",
+			want:  [][]types.Value{darkKnight, godfather, shawshank},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY 1 DESC",
+			want:  [][]types.Value{shawshank, godfather, darkKnight},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY director DESC, id - 2 * id",
+			want:  [][]types.Value{godfather, darkKnight, shawshank},
+		},
+		{
+			query: "SELECT title FROM films WHERE director = 1 ORDER BY release_date DESC",
+			want:  [][]types.Value{darkKnight, shawshank},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY release_date LIMIT 2",
+			want:  [][]types.Value{godfather, shawshank},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY release_date LIMIT 2 OFFSET 2",
+			want:  [][]types.Value{darkKnight},
+		},
+		{
+			query: "SELECT title FROM films ORDER BY release_date DESC OFFSET 1 ROW FETCH FIRST 1 ROW ONLY",
+			want:  [][]types.Value{shawshank},
+		},
+		{
+			query: "SELECT title FROM films LIMIT 1",
+			want:  [][]types.Value{shawshank},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			sampleData := storage.GetSampleData()
+			got := runQuery(t, sampleData.Database, c.query)
+			want := &types.Relation{Schema: titles, Rows: c.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	decimal := func(value string) types.Value {
+		return types.NewDecimal(value)
+	}
+	cases := []struct {
+		query string
+		want  *types.Relation
+	}{
+		{
+			query: "SELECT director, count(*), sum(id), avg(id), min(release_date), max(title) FROM films GROUP BY director",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "films.director", Type: types.TypeDecimal},
+					{Name: "count(*)", Type: types.TypeDecimal},
+					{Name: "sum(films.id)", Type: types.TypeDecimal, Nullable: true},
+					{Name: "avg(films.id)", Type: types.TypeDecimal, Nullable: true},
+					{Name: "min(films.release_date)", Type: types.TypeDate, Nullable: true},
+					{Name: "max(films.title)", Type: types.TypeText, Nullable: true},
+				}},
+				Rows: [][]types.Value{
+					{decimal("1"), decimal("2"), decimal("4"), decimal("2"), types.NewDate(1994, 9, 23), types.NewText("The Shawshank Redemption")},
+					{decimal("2"), decimal("1"), decimal("2"), decimal("2"), types.NewDate(1972, 3, 24), types.NewText("The Godfather")},
+				},
+			},
+		},
+		{
+			query: "SELECT people.name, count(*) FROM films JOIN people ON films.director = people.id GROUP BY people.name HAVING count(*) > 1",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "people.name", Type: types.TypeText},
+					{Name: "count(*)", Type: types.TypeDecimal},
+				}},
+				Rows: [][]types.Value{
+					{types.NewText("Frank Darabont"), decimal("2")},
+				},
+			},
+		},
+		{
+			query: "SELECT count(DISTINCT director), count(*) FROM films",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "count(distinct films.director)", Type: types.TypeDecimal},
+					{Name: "count(*)", Type: types.TypeDecimal},
+				}},
+				Rows: [][]types.Value{{decimal("2"), decimal("3")}},
+			},
+		},
+		{
+			query: "SELECT count(*), max(id) FROM films WHERE id > 5",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "count(*)", Type: types.TypeDecimal},
+					{Name: "max(films.id)", Type: types.TypeDecimal, Nullable: true},
+				}},
+				Rows: [][]types.Value{{decimal("0"), types.NewNull(types.TypeDecimal)}},
+			},
+		},
+		{
+			query: "SELECT director FROM films GROUP BY director ORDER BY count(*), max(id) DESC",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "films.director", Type: types.TypeDecimal},
+				}},
+				Rows: [][]types.Value{{decimal("2")}, {decimal("1")}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			sampleData := storage.GetSampleData()
+			got := runQuery(t, sampleData.Database, c.query)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAliases(t *testing.T) {
+	cases := []struct {
+		query string
+		want  *types.Relation
+	}{
+		{
+			query: "SELECT title AS name, id + 1 next_id FROM films f WHERE f.id = 2",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "name", Type: types.TypeText},
+					{Name: "next_id", Type: types.TypeDecimal, Nullable: true},
+				}},
+				Rows: [][]types.Value{{types.NewText("The Godfather"), types.NewDecimal("3")}},
+			},
+		},
+		{
+			query: "SELECT a.title, b.title FROM films a JOIN films AS b ON a.director = b.director WHERE a.id < b.id",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "a.title", Type: types.TypeText},
+					{Name: "b.title", Type: types.TypeText},
+				}},
+				Rows: [][]types.Value{{types.NewText("The Shawshank Redemption"), types.NewText("The Dark Knight")}},
+			},
+		},
+		{
+			query: "SELECT director d, count(*) AS total FROM films GROUP BY director ORDER BY total DESC, d",
+			want: &types.Relation{
+				Schema: types.TableSchema{Columns: []types.ColumnSchema{
+					{Name: "d", Type: types.TypeDecimal},
+					{Name: "total", Type: types.TypeDecimal},
+				}},
+				Rows: [][]types.Value{
+					{types.NewDecimal("1"), types.NewDecimal("2")},
+					{types.NewDecimal("2"), types.NewDecimal("1")},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			sampleData := storage.GetSampleData()
+			got := runQuery(t, sampleData.Database, c.query)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	db := sampleData.Database
+
+	if got := execute(t, db, "INSERT INTO films VALUES (4, 'Inception', 3, '2010-07-16')"); got != 1 {
+		t.Fatalf("got %d affected rows, want 1", got)
+	}
+	if got := execute(t, db, "INSERT INTO people (name, id) VALUES ('Christopher Nolan', 3), ('Stanley Kubrick', 4)"); got != 2 {
+		t.Fatalf("got %d affected rows, want 2", got)
+	}
+	if got := execute(t, db, "INSERT INTO people SELECT id + 10, title FROM films WHERE director = 3"); got != 1 {
+		t.Fatalf("got %d affected rows, want 1", got)
+	}
+
+	got := runQuery(t, db, "SELECT films.title, people.name, release_date FROM films JOIN people ON films.director = people.id WHERE films.id = 4")
+	want := [][]types.Value{{types.NewText("Inception"), types.NewText("Christopher Nolan"), types.NewDate(2010, 7, 16)}}
+	if !reflect.DeepEqual(got.Rows, want) {
+		t.Fatalf("got %v, want %v", got.Rows, want)
+	}
+	got = runQuery(t, db, "SELECT name FROM people WHERE id > 3")
+	want = [][]types.Value{{types.NewText("Stanley Kubrick")}, {types.NewText("Inception")}}
+	if !reflect.DeepEqual(got.Rows, want) {
+		t.Fatalf("got %v, want %v", got.Rows, want)
+	}
+}
+
+func TestUpdateDelete(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	db := sampleData.Database
+
+	if got := execute(t, db, "UPDATE films SET title = 'The Dark Knight Rises', release_date = '2012-07-20' WHERE id = 3"); got != 1 {
+		t.Fatalf("got %d affected rows, want 1", got)
+	}
+	if got := execute(t, db, "UPDATE films SET director = films.director + 1"); got != 3 {
+		t.Fatalf("got %d affected rows, want 3", got)
+	}
+	got := runQuery(t, db, "SELECT title, director, release_date FROM films WHERE id = 3")
+	want := [][]types.Value{{types.NewText("The Dark Knight Rises"), types.NewDecimal("2"), types.NewDate(2012, 7, 20)}}
+	if !reflect.DeepEqual(got.Rows, want) {
+		t.Fatalf("got %v, want %v", got.Rows, want)
+	}
+
+	if got := execute(t, db, "DELETE FROM films WHERE director = 2"); got != 2 {
+		t.Fatalf("got %d affected rows, want 2", got)
+	}
+	got = runQuery(t, db, "SELECT title FROM films")
+	want = [][]types.Value{{types.NewText("The Godfather")}}
+	if !reflect.DeepEqual(got.Rows, want) {
+		t.Fatalf("got %v, want %v", got.Rows, want)
+	}
+	if got := execute(t, db, "DELETE FROM films"); got != 1 {
+		t.Fatalf("got %d affected rows, want 1", got)
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	db := storage.NewDatabase()
+
+	execute(t, db, "CREATE TABLE reviews (film integer NOT NULL, rating numeric(3, 1), reviewer varchar(100) DEFAULT 'anonymous' NOT NULL, published date DEFAULT '2024-01-01', featured boolean)")
+	execute(t, db, "CREATE TABLE IF NOT EXISTS reviews (film integer)")
+	execute(t, db, "INSERT INTO reviews (film, rating) VALUES (1, 9.5)")
+	execute(t, db, "INSERT INTO reviews VALUES (2, NULL, 'critic', '2023-05-01', true)")
+
+	got := runQuery(t, db, "SELECT * FROM reviews")
+	want := &types.Relation{
+		Schema: types.TableSchema{Columns: []types.ColumnSchema{
+			{Name: "film", Type: types.TypeDecimal},
+			{Name: "rating", Type: types.TypeDecimal, Nullable: true},
+			{Name: "reviewer", Type: types.TypeText, Default: types.NewText("anonymous")},
+			{Name: "published", Type: types.TypeDate, Nullable: true, Default: types.NewDate(2024, 1, 1)},
+			{Name: "featured", Type: types.TypeBoolean, Nullable: true},
+		}},
+		Rows: [][]types.Value{
+			{types.NewDecimal("1"), types.NewDecimal("9.5"), types.NewText("anonymous"), types.NewDate(2024, 1, 1), types.NewNull(types.TypeBoolean)},
+			{types.NewDecimal("2"), types.NewNull(types.TypeDecimal), types.NewText("critic"), types.NewDate(2023, 5, 1), types.NewBoolean(true)},
+		},
+	}
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
+
+	execute(t, db, "DROP TABLE reviews")
+	execute(t, db, "DROP TABLE IF EXISTS reviews")
+	if _, err := db.GetTable("reviews"); err == nil {
+		t.Fatalf("expected reviews to be dropped")
+	}
+}
+
+func TestAlterTable(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	db := sampleData.Database
+
+	execute(t, db, "ALTER TABLE people ADD COLUMN born date")
+	execute(t, db, "ALTER TABLE people ADD active boolean DEFAULT true NOT NULL")
+	execute(t, db, "UPDATE people SET born = '1939-04-07' WHERE id = 2")
+	execute(t, db, "ALTER TABLE people RENAME COLUMN name TO full_name")
+	execute(t, db, "ALTER TABLE people DROP COLUMN active")
+	execute(t, db, "ALTER TABLE people RENAME TO directors")
+
+	got := runQuery(t, db, "SELECT films.title, full_name, born FROM films JOIN directors ON films.director = directors.id WHERE films.id = 2")
+	want := [][]types.Value{{types.NewText("The Godfather"), types.NewText("Francis Ford Coppola"), types.NewDate(1939, 4, 7)}}
+	if !reflect.DeepEqual(got.Rows, want) {
+		t.Fatalf("got %v, want %v", got.Rows, want)
+	}
+	if _, err := db.GetTable("people"); err == nil {
+		t.Fatalf("expected people to be renamed")
+	}
 }
@@ -70,6 +70,12 @@ func (l *Lexer) Lex() ([]Token, error) {
 func (l *Lexer) parseForPunctuation(r rune) {
 	switch {
 	case isPunctuation(r):
+		// parentheses and arithmetic operators never combine with their neighbours,
+		// so "((", "(*)" or "<-" split into separate tokens
+		if isStandalonePunctuation(r) || isStandalonePunctuation(l.input[l.from]) {
+			l.tokenForPunctuation()
+			l.changeState(LexerStatePunctuation)
+		}
 	case isDigitOrDot(r):
 		l.tokenForPunctuation()
 		l.changeState(LexerStateNumber)
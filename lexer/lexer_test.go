@@ -35,6 +35,16 @@ func TestLexer(t *testing.T) {
 			input: "select foo from bar where (x=123.45 or y<0) and z>= .4",
 			want:  `Select (Identifier "foo") From (Identifier "bar") Where OpenParen (Identifier "x") Eq (Number "123.45") Or (Identifier "y") Lt (Number "0") CloseParen And (Identifier "z") Gte (Number ".4")`,
 		},
+		{
+			name:  "runs tests successfully with nested parentheses",
+			input: "select foo from bar where ((x=1)or(not y))",
+			want:  `Select (Identifier "foo") From (Identifier "bar") Where OpenParen OpenParen (Identifier "x") Eq (Number "1") CloseParen Or OpenParen Not (Identifier "y") CloseParen CloseParen`,
+		},
+		{
+			name:  "runs tests successfully with arithmetic operators",
+			input: "select a+b, (c-1)*2, d/-e%3 from bar where x<-1",
+			want:  `Select (Identifier "a") Plus (Identifier "b") Comma OpenParen (Identifier "c") Minus (Number "1") CloseParen Star (Number "2") Comma (Identifier "d") Slash Minus (Identifier "e") Percent (Number "3") From (Identifier "bar") Where (Identifier "x") Lt Minus (Number "1")`,
+		},
 		{
 			name:  "runs tests successfully with null identifier",
 			input: "select * from temptable where x is not null",
@@ -78,7 +88,7 @@ func TestInvalidCases(t *testing.T) {
 		},
 		{
 			name:  "runs tests successfully with invalid input",
-			input: "select % from temptable",
+			input: "select # from temptable",
 		},
 	}
 
@@ -107,10 +117,10 @@ func TestLexError(t *testing.T) {
 			},
 		},
 		{
-			input: "select % from temptable",
+			input: "select # from temptable",
 			want: SyntaxError{
 				Position: 7,
-				Message:  `unexpected character '%'`,
+				Message:  `unexpected character '#'`,
 			},
 		},
 	}
@@ -22,6 +22,10 @@ const (
 	TokenTypeLte
 	TokenTypeGt
 	TokenTypeGte
+	TokenTypePlus
+	TokenTypeMinus
+	TokenTypeSlash
+	TokenTypePercent
 
 	TokenTypeSelect
 	TokenTypeFrom
@@ -35,11 +39,49 @@ const (
 	TokenTypeLeft
 	TokenTypeRight
 	TokenTypeInner
+	TokenTypeFull
 	TokenTypeOuter
 	TokenTypeJoin
 	TokenTypeOn
 	TokenTypeTrue
 	TokenTypeFalse
+	TokenTypeOrder
+	TokenTypeBy
+	TokenTypeAsc
+	TokenTypeDesc
+	TokenTypeNulls
+	TokenTypeFirst
+	TokenTypeLast
+	TokenTypeLimit
+	TokenTypeOffset
+	TokenTypeFetch
+	TokenTypeNext
+	TokenTypeRow
+	TokenTypeRows
+	TokenTypeOnly
+	TokenTypeGroup
+	TokenTypeHaving
+	TokenTypeDistinct
+	TokenTypeAs
+	TokenTypeInsert
+	TokenTypeInto
+	TokenTypeValues
+	TokenTypeUpdate
+	TokenTypeSet
+	TokenTypeDelete
+	TokenTypeCreate
+	TokenTypeDrop
+	TokenTypeTable
+	TokenTypeIf
+	TokenTypeExists
+	TokenTypeDefault
+	TokenTypeAlter
+	TokenTypeAdd
+	TokenTypeColumn
+	TokenTypeRename
+	TokenTypeTo
+	TokenTypeExplain
+	TokenTypeAnalyze
 )
 
 type BinaryOperator int
@@ -66,6 +108,36 @@ func (b BinaryOperator) String() string {
 	return binaryOperatorNames[b]
 }
 
+type ArithmeticOperator int
+
+const (
+	ArithmeticOperatorAdd ArithmeticOperator = iota
+	ArithmeticOperatorSub
+	ArithmeticOperatorMul
+	ArithmeticOperatorDiv
+	ArithmeticOperatorMod
+)
+
+var arithmeticOperatorNames = map[ArithmeticOperator]string{
+	ArithmeticOperatorAdd: "Add",
+	ArithmeticOperatorSub: "Sub",
+	ArithmeticOperatorMul: "Mul",
+	ArithmeticOperatorDiv: "Div",
+	ArithmeticOperatorMod: "Mod",
+}
+
+func (a ArithmeticOperator) String() string {
+	return arithmeticOperatorNames[a]
+}
+
+var TokenToArithmeticOperator = map[TokenType]ArithmeticOperator{
+	TokenTypePlus:    ArithmeticOperatorAdd,
+	TokenTypeMinus:   ArithmeticOperatorSub,
+	TokenTypeStar:    ArithmeticOperatorMul,
+	TokenTypeSlash:   ArithmeticOperatorDiv,
+	TokenTypePercent: ArithmeticOperatorMod,
+}
+
 var TokenToBinaryOperator = map[TokenType]BinaryOperator{
 	TokenTypeEq:    BinaryOperatorEq,
 	TokenTypeNotEq: BinaryOperatorNotEq,
@@ -107,6 +179,14 @@ func (t TokenType) String() string {
 		return "Gt"
 	case TokenTypeGte:
 		return "Gte"
+	case TokenTypePlus:
+		return "Plus"
+	case TokenTypeMinus:
+		return "Minus"
+	case TokenTypeSlash:
+		return "Slash"
+	case TokenTypePercent:
+		return "Percent"
 	case TokenTypeSelect:
 		return "Select"
 	case TokenTypeFrom:
@@ -131,6 +211,8 @@ func (t TokenType) String() string {
 		return "Right"
 	case TokenTypeInner:
 		return "Inner"
+	case TokenTypeFull:
+		return "Full"
 	case TokenTypeOuter:
 		return "Outer"
 	case TokenTypeJoin:
@@ -141,28 +223,140 @@ func (t TokenType) String() string {
 		return "True"
 	case TokenTypeFalse:
 		return "False"
+	case TokenTypeOrder:
+		return "Order"
+	case TokenTypeBy:
+		return "By"
+	case TokenTypeAsc:
+		return "Asc"
+	case TokenTypeDesc:
+		return "Desc"
+	case TokenTypeNulls:
+		return "Nulls"
+	case TokenTypeFirst:
+		return "First"
+	case TokenTypeLast:
+		return "Last"
+	case TokenTypeLimit:
+		return "Limit"
+	case TokenTypeOffset:
+		return "Offset"
+	case TokenTypeFetch:
+		return "Fetch"
+	case TokenTypeNext:
+		return "Next"
+	case TokenTypeRow:
+		return "Row"
+	case TokenTypeRows:
+		return "Rows"
+	case TokenTypeOnly:
+		return "Only"
+	case TokenTypeGroup:
+		return "Group"
+	case TokenTypeHaving:
+		return "Having"
+	case TokenTypeDistinct:
+		return "Distinct"
+	case TokenTypeAs:
+		return "As"
+	case TokenTypeInsert:
+		return "Insert"
+	case TokenTypeInto:
+		return "Into"
+	case TokenTypeValues:
+		return "Values"
+	case TokenTypeUpdate:
+		return "Update"
+	case TokenTypeSet:
+		return "Set"
+	case TokenTypeDelete:
+		return "Delete"
+	case TokenTypeCreate:
+		return "Create"
+	case TokenTypeDrop:
+		return "Drop"
+	case TokenTypeTable:
+		return "Table"
+	case TokenTypeIf:
+		return "If"
+	case TokenTypeExists:
+		return "Exists"
+	case TokenTypeDefault:
+		return "Default"
+	case TokenTypeAlter:
+		return "Alter"
+	case TokenTypeAdd:
+		return "Add"
+	case TokenTypeColumn:
+		return "Column"
+	case TokenTypeRename:
+		return "Rename"
+	case TokenTypeTo:
+		return "To"
+	case TokenTypeExplain:
+		return "Explain"
+	case TokenTypeAnalyze:
+		return "Analyze"
 	}
 	return fmt.Sprintf("Unknown token type %d", t)
 }
 
 var KeywordMap = map[string]TokenType{
-	"select": TokenTypeSelect,
-	"from":   TokenTypeFrom,
-	"where":  TokenTypeWhere,
-	"and":    TokenTypeAnd,
-	"or":     TokenTypeOr,
-	"not":    TokenTypeNot,
-	"in":     TokenTypeIn,
-	"is":     TokenTypeIs,
-	"null":   TokenTypeNull,
-	"left":   TokenTypeLeft,
-	"right":  TokenTypeRight,
-	"inner":  TokenTypeInner,
-	"outer":  TokenTypeOuter,
-	"join":   TokenTypeJoin,
-	"on":     TokenTypeOn,
-	"true":   TokenTypeTrue,
-	"false":  TokenTypeFalse,
+	"select":   TokenTypeSelect,
+	"from":     TokenTypeFrom,
+	"where":    TokenTypeWhere,
+	"and":      TokenTypeAnd,
+	"or":       TokenTypeOr,
+	"not":      TokenTypeNot,
+	"in":       TokenTypeIn,
+	"is":       TokenTypeIs,
+	"null":     TokenTypeNull,
+	"left":     TokenTypeLeft,
+	"right":    TokenTypeRight,
+	"inner":    TokenTypeInner,
+	"full":     TokenTypeFull,
+	"outer":    TokenTypeOuter,
+	"join":     TokenTypeJoin,
+	"on":       TokenTypeOn,
+	"true":     TokenTypeTrue,
+	"false":    TokenTypeFalse,
+	"order":    TokenTypeOrder,
+	"by":       TokenTypeBy,
+	"asc":      TokenTypeAsc,
+	"desc":     TokenTypeDesc,
+	"nulls":    TokenTypeNulls,
+	"first":    TokenTypeFirst,
+	"last":     TokenTypeLast,
+	"limit":    TokenTypeLimit,
+	"offset":   TokenTypeOffset,
+	"fetch":    TokenTypeFetch,
+	"next":     TokenTypeNext,
+	"row":      TokenTypeRow,
+	"rows":     TokenTypeRows,
+	"only":     TokenTypeOnly,
+	"group":    TokenTypeGroup,
+	"having":   TokenTypeHaving,
+	"distinct": TokenTypeDistinct,
+	"as":       TokenTypeAs,
+	"insert":   TokenTypeInsert,
+	"into":     TokenTypeInto,
+	"values":   TokenTypeValues,
+	"update":   TokenTypeUpdate,
+	"set":      TokenTypeSet,
+	"delete":   TokenTypeDelete,
+	"create":   TokenTypeCreate,
+	"drop":     TokenTypeDrop,
+	"table":    TokenTypeTable,
+	"if":       TokenTypeIf,
+	"exists":   TokenTypeExists,
+	"default":  TokenTypeDefault,
+	"alter":    TokenTypeAlter,
+	"add":      TokenTypeAdd,
+	"column":   TokenTypeColumn,
+	"rename":   TokenTypeRename,
+	"to":       TokenTypeTo,
+	"explain":  TokenTypeExplain,
+	"analyze":  TokenTypeAnalyze,
 }
 
 var SymbolMap = map[string]TokenType{
@@ -179,6 +373,10 @@ var SymbolMap = map[string]TokenType{
 	"<=": TokenTypeLte,
 	">":  TokenTypeGt,
 	">=": TokenTypeGte,
+	"+":  TokenTypePlus,
+	"-":  TokenTypeMinus,
+	"/":  TokenTypeSlash,
+	"%":  TokenTypePercent,
 }
 
 func isQuote(r rune) bool {
@@ -211,7 +409,15 @@ func isWhitespace(r rune) bool {
 
 func isPunctuation(r rune) bool {
 	switch r {
-	case ',', '.', ';', '(', ')', '=', '<', '>', '!', '*':
+	case ',', '.', ';', '(', ')', '=', '<', '>', '!', '*', '+', '-', '/', '%':
+		return true
+	}
+	return false
+}
+
+func isStandalonePunctuation(r rune) bool {
+	switch r {
+	case '(', ')', '+', '-', '/', '%':
 		return true
 	}
 	return false
@@ -8,35 +8,204 @@ import (
 
 type Parser[T any] func(*lexer.TokenList) (T, *lexer.TokenList, error)
 
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceComparison
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceUnary
+)
+
+// binaryPrecedence maps the infix operator tokens to their binding strength, higher binds tighter
+var binaryPrecedence = map[lexer.TokenType]int{
+	lexer.TokenTypeOr:    precedenceOr,
+	lexer.TokenTypeAnd:   precedenceAnd,
+	lexer.TokenTypeEq:    precedenceComparison,
+	lexer.TokenTypeNotEq: precedenceComparison,
+	lexer.TokenTypeLt:    precedenceComparison,
+	lexer.TokenTypeLte:   precedenceComparison,
+	lexer.TokenTypeGt:    precedenceComparison,
+	lexer.TokenTypeGte:   precedenceComparison,
+
+	lexer.TokenTypePlus:    precedenceAdditive,
+	lexer.TokenTypeMinus:   precedenceAdditive,
+	lexer.TokenTypeStar:    precedenceMultiplicative,
+	lexer.TokenTypeSlash:   precedenceMultiplicative,
+	lexer.TokenTypePercent: precedenceMultiplicative,
+}
+
 // ParseExpression parses an expression from a list of tokens and returns the parsed expression and the remaining tokens
 func ParseExpression(tokens *lexer.TokenList) (sql.Expression, *lexer.TokenList, error) {
-	left, remTokens, err := ParseValue(tokens)
+	return parseExpression(tokens, precedenceOr)
+}
+
+// parseExpression is a precedence climbing parser, it consumes operators binding at least as tight as minPrecedence
+func parseExpression(tokens *lexer.TokenList, minPrecedence int) (sql.Expression, *lexer.TokenList, error) {
+	left, remTokens, err := parseOperand(tokens)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	token, err := remTokens.Get(lexer.TokenTypeEq, lexer.TokenTypeNotEq, lexer.TokenTypeGt, lexer.TokenTypeLt, lexer.TokenTypeGte, lexer.TokenTypeLte)
+	for {
+		token, err := remTokens.Peek()
+		if err != nil {
+			// end of input
+			break
+		}
+		if token.Type == lexer.TokenTypeIs && precedenceComparison >= minPrecedence {
+			left, remTokens, err = parseIsNull(remTokens, left)
+			if err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		precedence, ok := binaryPrecedence[token.Type]
+		if !ok || precedence < minPrecedence {
+			break
+		}
+		_ = remTokens.Consume()
+
+		// all binary operators are left associative, so the right side only takes tighter operators
+		right, _, err := parseExpression(remTokens, precedence+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		left = newBinaryExpression(token.Type, left, right)
+	}
+	return left, remTokens, nil
+}
+
+// parseOperand parses a prefix operator, a parenthesised sub-expression or a value
+func parseOperand(tokens *lexer.TokenList) (sql.Expression, *lexer.TokenList, error) {
+	token, err := tokens.Peek()
 	if err != nil {
-		return left, remTokens, nil
+		return nil, nil, err
 	}
 
-	op := lexer.TokenToBinaryOperator[token.Type]
+	switch token.Type {
+	case lexer.TokenTypeNot:
+		_ = tokens.Consume()
+		expr, remTokens, err := parseExpression(tokens, precedenceNot)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &sql.Not{Expression: expr}, remTokens, nil
+	case lexer.TokenTypeMinus:
+		_ = tokens.Consume()
+		expr, remTokens, err := parseExpression(tokens, precedenceUnary)
+		if err != nil {
+			return nil, nil, err
+		}
+		// fold the sign into number literals so that -1 stays a constant
+		if number, ok := expr.(sql.NumberLiteral); ok {
+			return sql.NumberLiteral{Value: number.Value.Neg()}, remTokens, nil
+		}
+		return &sql.Negation{Expression: expr}, remTokens, nil
+	case lexer.TokenTypeIdentifier:
+		if len(tokens.Tokens) > 1 && tokens.Tokens[1].Type == lexer.TokenTypeOpenParen {
+			return ParseFunctionCall(tokens)
+		}
+		return ParseValue(tokens)
+	case lexer.TokenTypeOpenParen:
+		_ = tokens.Consume()
+		expr, remTokens, err := parseExpression(tokens, precedenceOr)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := remTokens.Consume(lexer.TokenTypeCloseParen); err != nil {
+			return nil, nil, err
+		}
+		return expr, remTokens, nil
+	default:
+		return ParseValue(tokens)
+	}
+}
 
-	right, remTokens, err := ParseValue(remTokens)
+// ParseFunctionCall parses a function call such as COUNT(*), COUNT(DISTINCT x) or SUM(x) and returns it and the remaining tokens
+func ParseFunctionCall(tokens *lexer.TokenList) (sql.Expression, *lexer.TokenList, error) {
+	name, err := tokens.Get(lexer.TokenTypeIdentifier)
 	if err != nil {
 		return nil, nil, err
 	}
-	result := &sql.BinaryOperation{
+	if err := tokens.Consume(lexer.TokenTypeOpenParen); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.FunctionCall{Name: name.Value}
+
+	if err := tokens.Consume(lexer.TokenTypeStar); err == nil {
+		result.Star = true
+	} else {
+		if err := tokens.Consume(lexer.TokenTypeDistinct); err == nil {
+			result.Distinct = true
+		}
+		if _, err := tokens.Peek(lexer.TokenTypeCloseParen); err != nil || result.Distinct {
+			result.Arguments, tokens, err = parseExpressions(tokens)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if err := tokens.Consume(lexer.TokenTypeCloseParen); err != nil {
+		return nil, nil, err
+	}
+	return result, tokens, nil
+}
+
+// parseExpressions parses a non-empty comma separated list of expressions
+func parseExpressions(tokens *lexer.TokenList) ([]sql.Expression, *lexer.TokenList, error) {
+	var result []sql.Expression
+	for {
+		expr, remTokens, err := ParseExpression(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, expr)
+
+		if err := remTokens.Consume(lexer.TokenTypeComma); err != nil {
+			break
+		}
+	}
+	return result, tokens, nil
+}
+
+// parseIsNull parses the postfix IS [NOT] NULL test applied to an already parsed expression
+func parseIsNull(tokens *lexer.TokenList, expr sql.Expression) (sql.Expression, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeIs); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.IsNull{Expression: expr}
+	if err := tokens.Consume(lexer.TokenTypeNot); err == nil {
+		result.Not = true
+	}
+	if err := tokens.Consume(lexer.TokenTypeNull); err != nil {
+		return nil, nil, err
+	}
+	return result, tokens, nil
+}
+
+func newBinaryExpression(operator lexer.TokenType, left, right sql.Expression) sql.Expression {
+	switch operator {
+	case lexer.TokenTypeAnd:
+		return &sql.And{Left: left, Right: right}
+	case lexer.TokenTypeOr:
+		return &sql.Or{Left: left, Right: right}
+	}
+	if arithmetic, ok := lexer.TokenToArithmeticOperator[operator]; ok {
+		return &sql.ArithmeticOperation{Left: left, Operator: arithmetic, Right: right}
+	}
+	return &sql.BinaryOperation{
 		Left:     left,
 		Right:    right,
-		Operator: op,
+		Operator: lexer.TokenToBinaryOperator[operator],
 	}
-	return result, remTokens, nil
 }
 
 // ParseValue parses a value from a list of tokens and returns the parsed value and the remaining tokens
 func ParseValue(tokens *lexer.TokenList) (sql.Expression, *lexer.TokenList, error) {
-	token, err := tokens.Peek(lexer.TokenTypeString, lexer.TokenTypeNumber, lexer.TokenTypeIdentifier, lexer.TokenTypeTrue, lexer.TokenTypeFalse)
+	token, err := tokens.Peek(lexer.TokenTypeString, lexer.TokenTypeNumber, lexer.TokenTypeIdentifier, lexer.TokenTypeTrue, lexer.TokenTypeFalse, lexer.TokenTypeNull)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -49,6 +218,9 @@ func ParseValue(tokens *lexer.TokenList) (sql.Expression, *lexer.TokenList, erro
 	case lexer.TokenTypeTrue, lexer.TokenTypeFalse:
 		_ = tokens.Consume()
 		return sql.Boolean{Value: token.Type == lexer.TokenTypeTrue}, tokens, nil
+	case lexer.TokenTypeNull:
+		_ = tokens.Consume()
+		return sql.Null{}, tokens, nil
 
 	default:
 		return ParseColumnReference(tokens)
@@ -126,6 +298,10 @@ func ParseExpressionList(tokens *lexer.TokenList) ([]sql.Expression, *lexer.Toke
 			return nil, nil, err
 		}
 		first = false
+		expr, remTokens, err = parseAlias(remTokens, expr)
+		if err != nil {
+			return nil, nil, err
+		}
 		result = append(result, expr)
 
 		err = remTokens.Consume(lexer.TokenTypeComma)
@@ -141,7 +317,38 @@ func ParseTableName(tokens *lexer.TokenList) (sql.TableName, *lexer.TokenList, e
 	if err != nil {
 		return sql.TableName{}, nil, err
 	}
-	return sql.TableName{Name: token.Value}, tokens, nil
+	alias, err := parseAliasName(tokens)
+	if err != nil {
+		return sql.TableName{}, nil, err
+	}
+	return sql.TableName{Name: token.Value, Alias: alias}, tokens, nil
+}
+
+// parseAlias wraps the expression when it is followed by an alias, written as AS name or just name
+func parseAlias(tokens *lexer.TokenList, expr sql.Expression) (sql.Expression, *lexer.TokenList, error) {
+	alias, err := parseAliasName(tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	if alias == "" {
+		return expr, tokens, nil
+	}
+	return &sql.AliasedExpression{Expression: expr, Alias: alias}, tokens, nil
+}
+
+// parseAliasName returns the alias following a table or expression, or an empty string if there is none
+func parseAliasName(tokens *lexer.TokenList) (string, error) {
+	if err := tokens.Consume(lexer.TokenTypeAs); err == nil {
+		token, err := tokens.Get(lexer.TokenTypeIdentifier)
+		if err != nil {
+			return "", err
+		}
+		return token.Value, nil
+	}
+	if token, err := tokens.Get(lexer.TokenTypeIdentifier); err == nil {
+		return token.Value, nil
+	}
+	return "", nil
 }
 
 func ParseTableReference(tokens *lexer.TokenList) (sql.TableReference, *lexer.TokenList, error) {
@@ -150,7 +357,7 @@ func ParseTableReference(tokens *lexer.TokenList) (sql.TableReference, *lexer.To
 		return nil, nil, err
 	}
 
-	token, err := tokens.Get(lexer.TokenTypeLeft, lexer.TokenTypeRight, lexer.TokenTypeJoin)
+	token, err := tokens.Get(lexer.TokenTypeLeft, lexer.TokenTypeRight, lexer.TokenTypeFull, lexer.TokenTypeInner, lexer.TokenTypeJoin)
 	if err != nil {
 		// No join
 		return left, remTokens, nil
@@ -163,16 +370,23 @@ func ParseTableReference(tokens *lexer.TokenList) (sql.TableReference, *lexer.To
 	switch token.Type {
 	case lexer.TokenTypeLeft:
 		join.Type = sql.JoinTypeLeft
-		_ = remTokens.Consume(lexer.TokenTypeOuter)
-		_ = remTokens.Consume(lexer.TokenTypeJoin)
 	case lexer.TokenTypeRight:
 		join.Type = sql.JoinTypeRight
-		_ = remTokens.Consume(lexer.TokenTypeOuter)
-		_ = remTokens.Consume(lexer.TokenTypeJoin)
+	case lexer.TokenTypeFull:
+		join.Type = sql.JoinTypeFull
 	default:
 		join.Type = sql.JoinTypeInner
 	}
 
+	if token.Type != lexer.TokenTypeJoin {
+		if join.Type != sql.JoinTypeInner {
+			_ = remTokens.Consume(lexer.TokenTypeOuter)
+		}
+		if err := remTokens.Consume(lexer.TokenTypeJoin); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	right, remTokens, err := ParseTableReference(remTokens)
 	if err != nil {
 		return nil, nil, err
@@ -194,9 +408,375 @@ func ParseTableReference(tokens *lexer.TokenList) (sql.TableReference, *lexer.To
 }
 
 func ParseStatement(tokens *lexer.TokenList) (sql.Statement, *lexer.TokenList, error) {
+	token, err := tokens.Peek(lexer.TokenTypeSelect, lexer.TokenTypeInsert, lexer.TokenTypeUpdate, lexer.TokenTypeDelete, lexer.TokenTypeCreate, lexer.TokenTypeDrop, lexer.TokenTypeAlter, lexer.TokenTypeExplain, lexer.TokenTypeAnalyze)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch token.Type {
+	case lexer.TokenTypeExplain:
+		return ParseExplainStatement(tokens)
+	case lexer.TokenTypeAnalyze:
+		return ParseAnalyzeStatement(tokens)
+	case lexer.TokenTypeAlter:
+		return ParseAlterTableStatement(tokens)
+	case lexer.TokenTypeCreate:
+		return ParseCreateTableStatement(tokens)
+	case lexer.TokenTypeDrop:
+		return ParseDropTableStatement(tokens)
+	case lexer.TokenTypeInsert:
+		return ParseInsertStatement(tokens)
+	case lexer.TokenTypeUpdate:
+		return ParseUpdateStatement(tokens)
+	case lexer.TokenTypeDelete:
+		return ParseDeleteStatement(tokens)
+	}
 	return ParseSelectStatement(tokens)
 }
 
+// ParseExplainStatement parses EXPLAIN [ANALYZE] followed by a SELECT statement
+func ParseExplainStatement(tokens *lexer.TokenList) (*sql.ExplainStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeExplain); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.ExplainStatement{}
+	if err := tokens.Consume(lexer.TokenTypeAnalyze); err == nil {
+		result.Analyze = true
+	}
+	query, remTokens, err := ParseSelectStatement(tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.Query = query
+	return result, remTokens, nil
+}
+
+// ParseAnalyzeStatement parses ANALYZE followed by an optional table name
+func ParseAnalyzeStatement(tokens *lexer.TokenList) (*sql.AnalyzeStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeAnalyze); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.AnalyzeStatement{}
+	if name, err := tokens.Get(lexer.TokenTypeIdentifier); err == nil {
+		result.Table = name.Value
+	}
+	return result, tokens, nil
+}
+
+// ParseCreateTableStatement parses CREATE TABLE [IF NOT EXISTS] name (column type [NOT NULL] [DEFAULT value], ...)
+func ParseCreateTableStatement(tokens *lexer.TokenList) (*sql.CreateTableStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeCreate); err != nil {
+		return nil, nil, err
+	}
+	if err := tokens.Consume(lexer.TokenTypeTable); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.CreateTableStatement{}
+	if err := tokens.Consume(lexer.TokenTypeIf); err == nil {
+		if err := tokens.Consume(lexer.TokenTypeNot); err != nil {
+			return nil, nil, err
+		}
+		if err := tokens.Consume(lexer.TokenTypeExists); err != nil {
+			return nil, nil, err
+		}
+		result.IfNotExists = true
+	}
+	name, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.Name = name.Value
+
+	if err := tokens.Consume(lexer.TokenTypeOpenParen); err != nil {
+		return nil, nil, err
+	}
+	for {
+		column, err := ParseColumnDefinition(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Columns = append(result.Columns, column)
+
+		if err := tokens.Consume(lexer.TokenTypeComma); err != nil {
+			break
+		}
+	}
+	if err := tokens.Consume(lexer.TokenTypeCloseParen); err != nil {
+		return nil, nil, err
+	}
+	return result, tokens, nil
+}
+
+// ParseColumnDefinition parses a column name followed by its type and constraints. Type parameters
+// such as the length of VARCHAR(255) are accepted but ignored, as values are not limited in size.
+func ParseColumnDefinition(tokens *lexer.TokenList) (sql.ColumnDefinition, error) {
+	name, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return sql.ColumnDefinition{}, err
+	}
+	typeName, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return sql.ColumnDefinition{}, err
+	}
+	result := sql.ColumnDefinition{Name: name.Value, Type: typeName.Value}
+
+	if err := tokens.Consume(lexer.TokenTypeOpenParen); err == nil {
+		for {
+			if err := tokens.Consume(lexer.TokenTypeNumber); err != nil {
+				return sql.ColumnDefinition{}, err
+			}
+			if err := tokens.Consume(lexer.TokenTypeComma); err != nil {
+				break
+			}
+		}
+		if err := tokens.Consume(lexer.TokenTypeCloseParen); err != nil {
+			return sql.ColumnDefinition{}, err
+		}
+	}
+
+	for {
+		token, err := tokens.Get(lexer.TokenTypeNot, lexer.TokenTypeNull, lexer.TokenTypeDefault)
+		if err != nil {
+			return result, nil
+		}
+		switch token.Type {
+		case lexer.TokenTypeNot:
+			if err := tokens.Consume(lexer.TokenTypeNull); err != nil {
+				return sql.ColumnDefinition{}, err
+			}
+			result.NotNull = true
+		case lexer.TokenTypeNull:
+			result.NotNull = false
+		case lexer.TokenTypeDefault:
+			result.Default, tokens, err = ParseExpression(tokens)
+			if err != nil {
+				return sql.ColumnDefinition{}, err
+			}
+		}
+	}
+}
+
+// ParseDropTableStatement parses DROP TABLE [IF EXISTS] name
+func ParseDropTableStatement(tokens *lexer.TokenList) (*sql.DropTableStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeDrop); err != nil {
+		return nil, nil, err
+	}
+	if err := tokens.Consume(lexer.TokenTypeTable); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.DropTableStatement{}
+	if err := tokens.Consume(lexer.TokenTypeIf); err == nil {
+		if err := tokens.Consume(lexer.TokenTypeExists); err != nil {
+			return nil, nil, err
+		}
+		result.IfExists = true
+	}
+	name, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.Name = name.Value
+	return result, tokens, nil
+}
+
+// ParseAlterTableStatement parses ALTER TABLE name followed by ADD [COLUMN] definition, DROP [COLUMN] name,
+// RENAME [COLUMN] from TO to or RENAME TO name
+func ParseAlterTableStatement(tokens *lexer.TokenList) (*sql.AlterTableStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeAlter); err != nil {
+		return nil, nil, err
+	}
+	if err := tokens.Consume(lexer.TokenTypeTable); err != nil {
+		return nil, nil, err
+	}
+	table, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &sql.AlterTableStatement{Table: table.Value}
+
+	token, err := tokens.Get(lexer.TokenTypeAdd, lexer.TokenTypeDrop, lexer.TokenTypeRename)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch token.Type {
+	case lexer.TokenTypeAdd:
+		_ = tokens.Consume(lexer.TokenTypeColumn)
+		column, err := ParseColumnDefinition(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Action = sql.AddColumn{Column: column}
+	case lexer.TokenTypeDrop:
+		_ = tokens.Consume(lexer.TokenTypeColumn)
+		name, err := tokens.Get(lexer.TokenTypeIdentifier)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Action = sql.DropColumn{Name: name.Value}
+	case lexer.TokenTypeRename:
+		if err := tokens.Consume(lexer.TokenTypeTo); err == nil {
+			name, err := tokens.Get(lexer.TokenTypeIdentifier)
+			if err != nil {
+				return nil, nil, err
+			}
+			result.Action = sql.RenameTable{To: name.Value}
+			break
+		}
+		_ = tokens.Consume(lexer.TokenTypeColumn)
+		from, err := tokens.Get(lexer.TokenTypeIdentifier)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := tokens.Consume(lexer.TokenTypeTo); err != nil {
+			return nil, nil, err
+		}
+		to, err := tokens.Get(lexer.TokenTypeIdentifier)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Action = sql.RenameColumn{From: from.Value, To: to.Value}
+	}
+	return result, tokens, nil
+}
+
+// ParseUpdateStatement parses UPDATE table SET column = value, ... [WHERE condition]
+func ParseUpdateStatement(tokens *lexer.TokenList) (*sql.UpdateStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeUpdate); err != nil {
+		return nil, nil, err
+	}
+	table, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tokens.Consume(lexer.TokenTypeSet); err != nil {
+		return nil, nil, err
+	}
+	result := &sql.UpdateStatement{Table: table.Value}
+
+	for {
+		column, err := tokens.Get(lexer.TokenTypeIdentifier)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := tokens.Consume(lexer.TokenTypeEq); err != nil {
+			return nil, nil, err
+		}
+		value, remTokens, err := ParseExpression(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Assignments = append(result.Assignments, sql.Assignment{Column: column.Value, Value: value})
+
+		if err := remTokens.Consume(lexer.TokenTypeComma); err != nil {
+			break
+		}
+	}
+
+	if err := tokens.Consume(lexer.TokenTypeWhere); err == nil {
+		result.Where, tokens, err = ParseExpression(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, tokens, nil
+}
+
+// ParseDeleteStatement parses DELETE FROM table [WHERE condition]
+func ParseDeleteStatement(tokens *lexer.TokenList) (*sql.DeleteStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeDelete); err != nil {
+		return nil, nil, err
+	}
+	if err := tokens.Consume(lexer.TokenTypeFrom); err != nil {
+		return nil, nil, err
+	}
+	table, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &sql.DeleteStatement{Table: table.Value}
+
+	if err := tokens.Consume(lexer.TokenTypeWhere); err == nil {
+		result.Where, tokens, err = ParseExpression(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, tokens, nil
+}
+
+// ParseInsertStatement parses INSERT INTO table [(columns)] VALUES (...), ... or INSERT INTO table [(columns)] SELECT ...
+func ParseInsertStatement(tokens *lexer.TokenList) (*sql.InsertStatement, *lexer.TokenList, error) {
+	if err := tokens.Consume(lexer.TokenTypeInsert); err != nil {
+		return nil, nil, err
+	}
+	if err := tokens.Consume(lexer.TokenTypeInto); err != nil {
+		return nil, nil, err
+	}
+	table, err := tokens.Get(lexer.TokenTypeIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &sql.InsertStatement{Table: table.Value}
+
+	if err := tokens.Consume(lexer.TokenTypeOpenParen); err == nil {
+		result.Columns, err = parseColumnNames(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := tokens.Consume(lexer.TokenTypeCloseParen); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	token, err := tokens.Peek(lexer.TokenTypeValues, lexer.TokenTypeSelect)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token.Type == lexer.TokenTypeSelect {
+		result.Query, tokens, err = ParseSelectStatement(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		return result, tokens, nil
+	}
+
+	_ = tokens.Consume(lexer.TokenTypeValues)
+	for {
+		if err := tokens.Consume(lexer.TokenTypeOpenParen); err != nil {
+			return nil, nil, err
+		}
+		row, remTokens, err := parseExpressions(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := remTokens.Consume(lexer.TokenTypeCloseParen); err != nil {
+			return nil, nil, err
+		}
+		result.Values = append(result.Values, row)
+
+		if err := remTokens.Consume(lexer.TokenTypeComma); err != nil {
+			break
+		}
+	}
+	return result, tokens, nil
+}
+
+// parseColumnNames parses a non-empty comma separated list of column names
+func parseColumnNames(tokens *lexer.TokenList) ([]string, error) {
+	var result []string
+	for {
+		token, err := tokens.Get(lexer.TokenTypeIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, token.Value)
+
+		if err := tokens.Consume(lexer.TokenTypeComma); err != nil {
+			break
+		}
+	}
+	return result, nil
+}
+
 func ParseSelectStatement(tokens *lexer.TokenList) (*sql.SelectStatement, *lexer.TokenList, error) {
 	err := tokens.Consume(lexer.TokenTypeSelect)
 	if err != nil {
@@ -228,9 +808,129 @@ func ParseSelectStatement(tokens *lexer.TokenList) (*sql.SelectStatement, *lexer
 			return nil, nil, err
 		}
 	}
+
+	err = remTokens.Consume(lexer.TokenTypeGroup)
+	if err == nil {
+		if err = remTokens.Consume(lexer.TokenTypeBy); err != nil {
+			return nil, nil, err
+		}
+		result.GroupBy, remTokens, err = parseExpressions(remTokens)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = remTokens.Consume(lexer.TokenTypeHaving)
+	if err == nil {
+		result.Having, remTokens, err = ParseExpression(remTokens)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = remTokens.Consume(lexer.TokenTypeOrder)
+	if err == nil {
+		if err = remTokens.Consume(lexer.TokenTypeBy); err != nil {
+			return nil, nil, err
+		}
+		result.OrderBy, remTokens, err = ParseOrderBy(remTokens)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	result.Limit, result.Offset, remTokens, err = ParseLimit(remTokens)
+	if err != nil {
+		return nil, nil, err
+	}
 	return &result, remTokens, nil
 }
 
+// ParseLimit parses the optional LIMIT n [OFFSET m] or OFFSET m [ROWS] [FETCH FIRST n ROWS ONLY] clauses,
+// returning nil for the parts that are absent
+func ParseLimit(tokens *lexer.TokenList) (limit, offset sql.Expression, remTokens *lexer.TokenList, err error) {
+	if err := tokens.Consume(lexer.TokenTypeLimit); err == nil {
+		limit, tokens, err = ParseExpression(tokens)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if err := tokens.Consume(lexer.TokenTypeOffset); err == nil {
+		offset, tokens, err = ParseExpression(tokens)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		_ = tokens.Consume(lexer.TokenTypeRow, lexer.TokenTypeRows)
+	}
+
+	if limit != nil {
+		return limit, offset, tokens, nil
+	}
+
+	if err := tokens.Consume(lexer.TokenTypeFetch); err == nil {
+		if err := tokens.Consume(lexer.TokenTypeFirst, lexer.TokenTypeNext); err != nil {
+			return nil, nil, nil, err
+		}
+		// the row count is optional and defaults to a single row
+		limit = sql.NumberLiteral{Value: types.NewDecimal("1")}
+		if _, err := tokens.Peek(lexer.TokenTypeRow, lexer.TokenTypeRows); err != nil {
+			limit, tokens, err = ParseExpression(tokens)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		if err := tokens.Consume(lexer.TokenTypeRow, lexer.TokenTypeRows); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := tokens.Consume(lexer.TokenTypeOnly); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return limit, offset, tokens, nil
+}
+
+// ParseOrderBy parses the comma separated ordering terms following ORDER BY and returns them and the remaining tokens
+func ParseOrderBy(tokens *lexer.TokenList) ([]sql.OrderingTerm, *lexer.TokenList, error) {
+	var result []sql.OrderingTerm
+	for {
+		term, remTokens, err := ParseOrderingTerm(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, term)
+
+		if err := remTokens.Consume(lexer.TokenTypeComma); err != nil {
+			break
+		}
+	}
+	return result, tokens, nil
+}
+
+// ParseOrderingTerm parses an expression with its optional ASC/DESC and NULLS FIRST/LAST modifiers
+func ParseOrderingTerm(tokens *lexer.TokenList) (sql.OrderingTerm, *lexer.TokenList, error) {
+	expr, remTokens, err := ParseExpression(tokens)
+	if err != nil {
+		return sql.OrderingTerm{}, nil, err
+	}
+	term := sql.OrderingTerm{Expression: expr}
+
+	if token, err := remTokens.Get(lexer.TokenTypeAsc, lexer.TokenTypeDesc); err == nil {
+		term.Descending = token.Type == lexer.TokenTypeDesc
+	}
+	if err := remTokens.Consume(lexer.TokenTypeNulls); err == nil {
+		token, err := remTokens.Get(lexer.TokenTypeFirst, lexer.TokenTypeLast)
+		if err != nil {
+			return sql.OrderingTerm{}, nil, err
+		}
+		term.Nulls = sql.NullsLast
+		if token.Type == lexer.TokenTypeFirst {
+			term.Nulls = sql.NullsFirst
+		}
+	}
+	return term, remTokens, nil
+}
+
 func Parse(input string) (sql.Statement, error) {
 	tokens, err := lexer.Tokenize(input)
 	if err != nil {
@@ -240,7 +940,7 @@ func Parse(input string) (sql.Statement, error) {
 		Input:  input,
 		Tokens: tokens,
 	}
-	stmt, remToken, err := ParseSelectStatement(tokenList)
+	stmt, remToken, err := ParseStatement(tokenList)
 	if err != nil {
 		return nil, err
 	}
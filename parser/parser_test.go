@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/Vignesh-Rajarajan/go-db/lexer"
 	"github.com/Vignesh-Rajarajan/go-db/sql"
+	"github.com/Vignesh-Rajarajan/go-db/types"
 	"reflect"
 	"testing"
 )
@@ -95,6 +96,221 @@ func TestParseValidExpression(t *testing.T) {
 	}
 }
 
+func TestParseBooleanExpression(t *testing.T) {
+	eq := func(name string, value string) sql.Expression {
+		return &sql.BinaryOperation{
+			Left:     sql.ColumnReference{Name: name},
+			Operator: lexer.BinaryOperatorEq,
+			Right:    sql.StringLiteral{Value: value},
+		}
+	}
+	a, b, c := eq("a", "x"), eq("b", "y"), eq("c", "z")
+
+	cases := []struct {
+		input string
+		want  sql.Expression
+	}{
+		{
+			input: "a = 'x' AND b = 'y'",
+			want:  &sql.And{Left: a, Right: b},
+		},
+		{
+			input: "a = 'x' OR b = 'y' AND c = 'z'",
+			want:  &sql.Or{Left: a, Right: &sql.And{Left: b, Right: c}},
+		},
+		{
+			input: "a = 'x' AND b = 'y' OR c = 'z'",
+			want:  &sql.Or{Left: &sql.And{Left: a, Right: b}, Right: c},
+		},
+		{
+			input: "a = 'x' AND b = 'y' AND c = 'z'",
+			want:  &sql.And{Left: &sql.And{Left: a, Right: b}, Right: c},
+		},
+		{
+			input: "NOT a = 'x' AND b = 'y'",
+			want:  &sql.And{Left: &sql.Not{Expression: a}, Right: b},
+		},
+		{
+			input: "NOT NOT a = 'x'",
+			want:  &sql.Not{Expression: &sql.Not{Expression: a}},
+		},
+		{
+			input: "a = 'x' AND (b = 'y' OR c = 'z')",
+			want:  &sql.And{Left: a, Right: &sql.Or{Left: b, Right: c}},
+		},
+		{
+			input: "NOT (a = 'x' OR b = 'y')",
+			want:  &sql.Not{Expression: &sql.Or{Left: a, Right: b}},
+		},
+		{
+			input: "((a = 'x'))",
+			want:  a,
+		},
+		{
+			input: "a IS NULL",
+			want:  &sql.IsNull{Expression: sql.ColumnReference{Name: "a"}},
+		},
+		{
+			input: "a IS NOT NULL AND NOT b IS NULL",
+			want: &sql.And{
+				Left:  &sql.IsNull{Expression: sql.ColumnReference{Name: "a"}, Not: true},
+				Right: &sql.Not{Expression: &sql.IsNull{Expression: sql.ColumnReference{Name: "b"}}},
+			},
+		},
+		{
+			input: "a = NULL",
+			want: &sql.BinaryOperation{
+				Left:     sql.ColumnReference{Name: "a"},
+				Operator: lexer.BinaryOperatorEq,
+				Right:    sql.Null{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseExpression", ParseExpression, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"a = 'x' AND",
+		"(a = 'x'",
+		"NOT",
+		"a = 'x' OR OR b = 'y'",
+		"a IS 'x'",
+		"a IS NOT",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseExpression", ParseExpression, c)
+		})
+	}
+}
+
+func TestParseArithmeticExpression(t *testing.T) {
+	column := func(name string) sql.Expression {
+		return sql.ColumnReference{Name: name}
+	}
+	number := func(value string) sql.Expression {
+		return sql.NumberLiteral{Value: types.NewDecimal(value)}
+	}
+	op := func(left sql.Expression, operator lexer.ArithmeticOperator, right sql.Expression) sql.Expression {
+		return &sql.ArithmeticOperation{Left: left, Operator: operator, Right: right}
+	}
+
+	cases := []struct {
+		input string
+		want  sql.Expression
+	}{
+		{
+			input: "a + b * c",
+			want:  op(column("a"), lexer.ArithmeticOperatorAdd, op(column("b"), lexer.ArithmeticOperatorMul, column("c"))),
+		},
+		{
+			input: "a - b - c",
+			want:  op(op(column("a"), lexer.ArithmeticOperatorSub, column("b")), lexer.ArithmeticOperatorSub, column("c")),
+		},
+		{
+			input: "(a + b) % 2",
+			want:  op(op(column("a"), lexer.ArithmeticOperatorAdd, column("b")), lexer.ArithmeticOperatorMod, number("2")),
+		},
+		{
+			input: "a / -2",
+			want:  op(column("a"), lexer.ArithmeticOperatorDiv, number("-2")),
+		},
+		{
+			input: "-a * b",
+			want:  op(&sql.Negation{Expression: column("a")}, lexer.ArithmeticOperatorMul, column("b")),
+		},
+		{
+			input: "a + 1 > b * 2",
+			want: &sql.BinaryOperation{
+				Left:     op(column("a"), lexer.ArithmeticOperatorAdd, number("1")),
+				Operator: lexer.BinaryOperatorGt,
+				Right:    op(column("b"), lexer.ArithmeticOperatorMul, number("2")),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseExpression", ParseExpression, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"a +",
+		"* a",
+		"a + * b",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseExpression", ParseExpression, c)
+		})
+	}
+}
+
+func TestParseFunctionCall(t *testing.T) {
+	column := func(name string) sql.Expression {
+		return sql.ColumnReference{Name: name}
+	}
+
+	cases := []struct {
+		input string
+		want  sql.Expression
+	}{
+		{
+			input: "count(*)",
+			want:  &sql.FunctionCall{Name: "count", Star: true},
+		},
+		{
+			input: "SUM(a)",
+			want:  &sql.FunctionCall{Name: "SUM", Arguments: []sql.Expression{column("a")}},
+		},
+		{
+			input: "count(DISTINCT a)",
+			want:  &sql.FunctionCall{Name: "count", Arguments: []sql.Expression{column("a")}, Distinct: true},
+		},
+		{
+			input: "f(a, b + 1)",
+			want: &sql.FunctionCall{Name: "f", Arguments: []sql.Expression{
+				column("a"),
+				&sql.ArithmeticOperation{Left: column("b"), Operator: lexer.ArithmeticOperatorAdd, Right: sql.NumberLiteral{Value: types.NewDecimal("1")}},
+			}},
+		},
+		{
+			input: "f()",
+			want:  &sql.FunctionCall{Name: "f"},
+		},
+		{
+			input: "max(a) > 1",
+			want: &sql.BinaryOperation{
+				Left:     &sql.FunctionCall{Name: "max", Arguments: []sql.Expression{column("a")}},
+				Operator: lexer.BinaryOperatorGt,
+				Right:    sql.NumberLiteral{Value: types.NewDecimal("1")},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseExpression", ParseExpression, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"count(",
+		"count(DISTINCT)",
+		"sum(a,)",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseExpression", ParseExpression, c)
+		})
+	}
+}
+
 func TestParseSelectList(t *testing.T) {
 	cases := []struct {
 		input string
@@ -124,6 +340,16 @@ func TestParseSelectList(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "foo AS f, count(*) total, bar",
+			want: sql.ExpressionList{
+				Expressions: []sql.Expression{
+					&sql.AliasedExpression{Expression: sql.ColumnReference{Name: "foo"}, Alias: "f"},
+					&sql.AliasedExpression{Expression: &sql.FunctionCall{Name: "count", Star: true}, Alias: "total"},
+					sql.ColumnReference{Name: "bar"},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -134,6 +360,8 @@ func TestParseSelectList(t *testing.T) {
 
 	invalid := []string{
 		"foo,",
+		"foo AS",
+		"foo AS 'f'",
 	}
 	for _, c := range invalid {
 		t.Run(c, func(t *testing.T) {
@@ -183,6 +411,23 @@ func TestParseTableReference(t *testing.T) {
 			input: "foo",
 			want:  sql.TableName{Name: "foo"},
 		},
+		{
+			input: "foo AS f",
+			want:  sql.TableName{Name: "foo", Alias: "f"},
+		},
+		{
+			input: "foo a join foo b on a.x = b.x",
+			want: &sql.Join{
+				Left:  sql.TableName{Name: "foo", Alias: "a"},
+				Right: sql.TableName{Name: "foo", Alias: "b"},
+				Condition: &sql.BinaryOperation{
+					Left:     sql.ColumnReference{Name: "x", Relation: "a"},
+					Operator: lexer.BinaryOperatorEq,
+					Right:    sql.ColumnReference{Name: "x", Relation: "b"},
+				},
+				Type: sql.JoinTypeInner,
+			},
+		},
 		{
 			input: "foo join bar on foo.x = bar.y",
 			want: &sql.Join{
@@ -201,6 +446,42 @@ func TestParseTableReference(t *testing.T) {
 				Type:      sql.JoinTypeLeft,
 			},
 		},
+		{
+			input: "foo inner join bar on foo.x = bar.y",
+			want: &sql.Join{
+				Left:      sql.TableName{Name: "foo"},
+				Right:     sql.TableName{Name: "bar"},
+				Condition: &condition,
+				Type:      sql.JoinTypeInner,
+			},
+		},
+		{
+			input: "foo right join bar on foo.x = bar.y",
+			want: &sql.Join{
+				Left:      sql.TableName{Name: "foo"},
+				Right:     sql.TableName{Name: "bar"},
+				Condition: &condition,
+				Type:      sql.JoinTypeRight,
+			},
+		},
+		{
+			input: "foo full join bar on foo.x = bar.y",
+			want: &sql.Join{
+				Left:      sql.TableName{Name: "foo"},
+				Right:     sql.TableName{Name: "bar"},
+				Condition: &condition,
+				Type:      sql.JoinTypeFull,
+			},
+		},
+		{
+			input: "foo full outer join bar on foo.x = bar.y",
+			want: &sql.Join{
+				Left:      sql.TableName{Name: "foo"},
+				Right:     sql.TableName{Name: "bar"},
+				Condition: &condition,
+				Type:      sql.JoinTypeFull,
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -208,6 +489,17 @@ func TestParseTableReference(t *testing.T) {
 			checkParser(t, "ParseTableReference", ParseTableReference, c.input, c.want)
 		})
 	}
+
+	invalid := []string{
+		"foo left bar on foo.x = bar.y",
+		"foo inner outer join bar on foo.x = bar.y",
+		"foo full join bar",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseTableReference", ParseTableReference, c)
+		})
+	}
 }
 
 func TestParseSelectStatement(t *testing.T) {
@@ -242,12 +534,439 @@ func TestParseSelectStatement(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "select x from foo where x > 1 order by x desc, 2 nulls first, y asc nulls last",
+			want: sql.SelectStatement{
+				What: sql.ExpressionList{
+					Expressions: []sql.Expression{sql.ColumnReference{Name: "x"}},
+				},
+				From: sql.TableName{Name: "foo"},
+				Where: &sql.BinaryOperation{
+					Left:     sql.ColumnReference{Name: "x"},
+					Operator: lexer.BinaryOperatorGt,
+					Right:    sql.NumberLiteral{Value: types.NewDecimal("1")},
+				},
+				OrderBy: []sql.OrderingTerm{
+					{Expression: sql.ColumnReference{Name: "x"}, Descending: true},
+					{Expression: sql.NumberLiteral{Value: types.NewDecimal("2")}, Nulls: sql.NullsFirst},
+					{Expression: sql.ColumnReference{Name: "y"}, Nulls: sql.NullsLast},
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.input, func(t *testing.T) {
 			checkParser(t, "ParseSelectStatement", ParseSelectStatement, c.input, &c.want)
 		})
 	}
+
+	invalid := []string{
+		"select x from foo limit",
+		"select x from foo offset 1 fetch 2 rows only",
+		"select x from foo fetch first 2 only",
+		"select x from foo fetch first 2 rows",
+		"select x from foo order x",
+		"select x from foo order by",
+		"select x from foo order by x nulls",
+		"select x from foo order by x,",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseSelectStatement", ParseSelectStatement, c)
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	number := func(value string) sql.Expression {
+		return sql.NumberLiteral{Value: types.NewDecimal(value)}
+	}
+
+	cases := []struct {
+		input         string
+		limit, offset sql.Expression
+	}{
+		{input: "select * from foo"},
+		{input: "select * from foo limit 10", limit: number("10")},
+		{input: "select * from foo limit 10 offset 20", limit: number("10"), offset: number("20")},
+		{input: "select * from foo offset 20", offset: number("20")},
+		{input: "select * from foo offset 20 rows", offset: number("20")},
+		{input: "select * from foo fetch first 5 rows only", limit: number("5")},
+		{input: "select * from foo fetch next row only", limit: number("1")},
+		{input: "select * from foo offset 1 row fetch next 2 rows only", limit: number("2"), offset: number("1")},
+		{input: "select * from foo order by x limit 3", limit: number("3")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			tokens, err := lexer.Tokenize(c.input)
+			if err != nil {
+				t.Fatalf("unexpected error while tokenizing: %v", err)
+			}
+			stmt, remaining, err := ParseSelectStatement(&lexer.TokenList{Input: c.input, Tokens: tokens})
+			if err != nil {
+				t.Fatalf("unexpected error while parsing %v", err)
+			}
+			if remaining.Len() != 0 {
+				t.Fatalf("unexpected remaining tokens, did not get fully parsed: %v", remaining)
+			}
+			if !reflect.DeepEqual(stmt.Limit, c.limit) || !reflect.DeepEqual(stmt.Offset, c.offset) {
+				t.Fatalf("got limit %v offset %v, want limit %v offset %v", stmt.Limit, stmt.Offset, c.limit, c.offset)
+			}
+		})
+	}
+}
+
+func TestParseGroupBy(t *testing.T) {
+	column := func(name string) sql.Expression {
+		return sql.ColumnReference{Name: name}
+	}
+	count := &sql.FunctionCall{Name: "count", Star: true}
+
+	cases := []struct {
+		input   string
+		groupBy []sql.Expression
+		having  sql.Expression
+	}{
+		{input: "select * from foo"},
+		{input: "select a from foo group by a", groupBy: []sql.Expression{column("a")}},
+		{input: "select a, b from foo group by a, b order by a", groupBy: []sql.Expression{column("a"), column("b")}},
+		{
+			input:   "select a from foo where b > 1 group by a having count(*) > 1 limit 1",
+			groupBy: []sql.Expression{column("a")},
+			having:  &sql.BinaryOperation{Left: count, Operator: lexer.BinaryOperatorGt, Right: sql.NumberLiteral{Value: types.NewDecimal("1")}},
+		},
+		{
+			input:  "select count(*) from foo having count(*) > 1",
+			having: &sql.BinaryOperation{Left: count, Operator: lexer.BinaryOperatorGt, Right: sql.NumberLiteral{Value: types.NewDecimal("1")}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			tokens, err := lexer.Tokenize(c.input)
+			if err != nil {
+				t.Fatalf("unexpected error while tokenizing: %v", err)
+			}
+			stmt, remaining, err := ParseSelectStatement(&lexer.TokenList{Input: c.input, Tokens: tokens})
+			if err != nil {
+				t.Fatalf("unexpected error while parsing %v", err)
+			}
+			if remaining.Len() != 0 {
+				t.Fatalf("unexpected remaining tokens, did not get fully parsed: %v", remaining)
+			}
+			if !reflect.DeepEqual(stmt.GroupBy, c.groupBy) || !reflect.DeepEqual(stmt.Having, c.having) {
+				t.Fatalf("got group by %v having %v, want group by %v having %v", stmt.GroupBy, stmt.Having, c.groupBy, c.having)
+			}
+		})
+	}
+}
+
+func TestParseInsertStatement(t *testing.T) {
+	number := func(value string) sql.Expression {
+		return sql.NumberLiteral{Value: types.NewDecimal(value)}
+	}
+
+	cases := []struct {
+		input string
+		want  *sql.InsertStatement
+	}{
+		{
+			input: "insert into foo values (1, 'a')",
+			want: &sql.InsertStatement{
+				Table:  "foo",
+				Values: [][]sql.Expression{{number("1"), sql.StringLiteral{Value: "a"}}},
+			},
+		},
+		{
+			input: "insert into foo (x, y) values (1, null), (-2, 3 + 4)",
+			want: &sql.InsertStatement{
+				Table:   "foo",
+				Columns: []string{"x", "y"},
+				Values: [][]sql.Expression{
+					{number("1"), sql.Null{}},
+					{number("-2"), &sql.ArithmeticOperation{Left: number("3"), Operator: lexer.ArithmeticOperatorAdd, Right: number("4")}},
+				},
+			},
+		},
+		{
+			input: "insert into foo (x) select y from bar",
+			want: &sql.InsertStatement{
+				Table:   "foo",
+				Columns: []string{"x"},
+				Query: &sql.SelectStatement{
+					What: sql.ExpressionList{Expressions: []sql.Expression{sql.ColumnReference{Name: "y"}}},
+					From: sql.TableName{Name: "bar"},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseInsertStatement", ParseInsertStatement, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"insert foo values (1)",
+		"insert into foo",
+		"insert into foo values",
+		"insert into foo values ()",
+		"insert into foo values (1,)",
+		"insert into foo () values (1)",
+		"insert into foo (x values (1)",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseInsertStatement", ParseInsertStatement, c)
+		})
+	}
+}
+
+func TestParseUpdateStatement(t *testing.T) {
+	cases := []struct {
+		input string
+		want  *sql.UpdateStatement
+	}{
+		{
+			input: "update foo set x = 1",
+			want: &sql.UpdateStatement{
+				Table:       "foo",
+				Assignments: []sql.Assignment{{Column: "x", Value: sql.NumberLiteral{Value: types.NewDecimal("1")}}},
+			},
+		},
+		{
+			input: "update foo set x = x + 1, y = null where foo.x > 2",
+			want: &sql.UpdateStatement{
+				Table: "foo",
+				Assignments: []sql.Assignment{
+					{Column: "x", Value: &sql.ArithmeticOperation{
+						Left:     sql.ColumnReference{Name: "x"},
+						Operator: lexer.ArithmeticOperatorAdd,
+						Right:    sql.NumberLiteral{Value: types.NewDecimal("1")},
+					}},
+					{Column: "y", Value: sql.Null{}},
+				},
+				Where: &sql.BinaryOperation{
+					Left:     sql.ColumnReference{Relation: "foo", Name: "x"},
+					Operator: lexer.BinaryOperatorGt,
+					Right:    sql.NumberLiteral{Value: types.NewDecimal("2")},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseUpdateStatement", ParseUpdateStatement, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"update foo",
+		"update foo set",
+		"update foo set x",
+		"update foo set x = 1,",
+		"update foo set x = 1 where",
+		"update set x = 1",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseUpdateStatement", ParseUpdateStatement, c)
+		})
+	}
+}
+
+func TestParseDeleteStatement(t *testing.T) {
+	cases := []struct {
+		input string
+		want  *sql.DeleteStatement
+	}{
+		{
+			input: "delete from foo",
+			want:  &sql.DeleteStatement{Table: "foo"},
+		},
+		{
+			input: "delete from foo where x is null",
+			want: &sql.DeleteStatement{
+				Table: "foo",
+				Where: &sql.IsNull{Expression: sql.ColumnReference{Name: "x"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseDeleteStatement", ParseDeleteStatement, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"delete foo",
+		"delete from",
+		"delete from foo where",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseDeleteStatement", ParseDeleteStatement, c)
+		})
+	}
+}
+
+func TestParseCreateTableStatement(t *testing.T) {
+	cases := []struct {
+		input string
+		want  *sql.CreateTableStatement
+	}{
+		{
+			input: "create table foo (x decimal)",
+			want: &sql.CreateTableStatement{
+				Name:    "foo",
+				Columns: []sql.ColumnDefinition{{Name: "x", Type: "decimal"}},
+			},
+		},
+		{
+			input: "create table if not exists foo (id integer not null, name varchar(255) null, price numeric(10, 2) default 0 not null, created date default '2024-01-01')",
+			want: &sql.CreateTableStatement{
+				Name:        "foo",
+				IfNotExists: true,
+				Columns: []sql.ColumnDefinition{
+					{Name: "id", Type: "integer", NotNull: true},
+					{Name: "name", Type: "varchar"},
+					{Name: "price", Type: "numeric", NotNull: true, Default: sql.NumberLiteral{Value: types.NewDecimal("0")}},
+					{Name: "created", Type: "date", Default: sql.StringLiteral{Value: "2024-01-01"}},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseCreateTableStatement", ParseCreateTableStatement, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"create foo (x decimal)",
+		"create table foo",
+		"create table foo ()",
+		"create table foo (x)",
+		"create table foo (x decimal,)",
+		"create table foo (x decimal not)",
+		"create table foo (x decimal default)",
+		"create table foo (x varchar())",
+		"create table if exists foo (x decimal)",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseCreateTableStatement", ParseCreateTableStatement, c)
+		})
+	}
+}
+
+func TestParseDropTableStatement(t *testing.T) {
+	checkParser(t, "ParseDropTableStatement", ParseDropTableStatement, "drop table foo", &sql.DropTableStatement{Name: "foo"})
+	checkParser(t, "ParseDropTableStatement", ParseDropTableStatement, "drop table if exists foo", &sql.DropTableStatement{Name: "foo", IfExists: true})
+
+	invalid := []string{
+		"drop foo",
+		"drop table",
+		"drop table if foo",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseDropTableStatement", ParseDropTableStatement, c)
+		})
+	}
+}
+
+func TestParseExplainStatement(t *testing.T) {
+	query := &sql.SelectStatement{What: sql.Star{}, From: sql.TableName{Name: "foo"}}
+	checkParser(t, "ParseExplainStatement", ParseExplainStatement, "explain select * from foo", &sql.ExplainStatement{Query: query})
+	checkParser(t, "ParseExplainStatement", ParseExplainStatement, "explain analyze select * from foo", &sql.ExplainStatement{Query: query, Analyze: true})
+
+	invalid := []string{
+		"explain",
+		"explain analyze",
+		"explain delete from foo",
+		"analyze select * from foo",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseExplainStatement", ParseExplainStatement, c)
+		})
+	}
+}
+
+func TestParseAnalyzeStatement(t *testing.T) {
+	checkParser(t, "ParseAnalyzeStatement", ParseAnalyzeStatement, "analyze", &sql.AnalyzeStatement{})
+	checkParser(t, "ParseAnalyzeStatement", ParseAnalyzeStatement, "analyze foo", &sql.AnalyzeStatement{Table: "foo"})
+	checkParserInvalid(t, "ParseAnalyzeStatement", ParseAnalyzeStatement, "foo")
+
+	if _, err := Parse("analyze foo bar"); err == nil {
+		t.Errorf("Parse() = nil, want error for a second table name")
+	}
+}
+
+func TestParseAlterTableStatement(t *testing.T) {
+	cases := []struct {
+		input string
+		want  *sql.AlterTableStatement
+	}{
+		{
+			input: "alter table foo add column x decimal default 1 not null",
+			want: &sql.AlterTableStatement{Table: "foo", Action: sql.AddColumn{
+				Column: sql.ColumnDefinition{Name: "x", Type: "decimal", NotNull: true, Default: sql.NumberLiteral{Value: types.NewDecimal("1")}},
+			}},
+		},
+		{
+			input: "alter table foo add x text",
+			want:  &sql.AlterTableStatement{Table: "foo", Action: sql.AddColumn{Column: sql.ColumnDefinition{Name: "x", Type: "text"}}},
+		},
+		{
+			input: "alter table foo drop column x",
+			want:  &sql.AlterTableStatement{Table: "foo", Action: sql.DropColumn{Name: "x"}},
+		},
+		{
+			input: "alter table foo drop x",
+			want:  &sql.AlterTableStatement{Table: "foo", Action: sql.DropColumn{Name: "x"}},
+		},
+		{
+			input: "alter table foo rename column x to y",
+			want:  &sql.AlterTableStatement{Table: "foo", Action: sql.RenameColumn{From: "x", To: "y"}},
+		},
+		{
+			input: "alter table foo rename x to y",
+			want:  &sql.AlterTableStatement{Table: "foo", Action: sql.RenameColumn{From: "x", To: "y"}},
+		},
+		{
+			input: "alter table foo rename to bar",
+			want:  &sql.AlterTableStatement{Table: "foo", Action: sql.RenameTable{To: "bar"}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			checkParser(t, "ParseAlterTableStatement", ParseAlterTableStatement, c.input, c.want)
+		})
+	}
+
+	invalid := []string{
+		"alter foo add x text",
+		"alter table foo",
+		"alter table foo add column x",
+		"alter table foo drop column",
+		"alter table foo rename column x",
+		"alter table foo rename x y",
+		"alter table foo rename to",
+		"alter table foo set x = 1",
+	}
+	for _, c := range invalid {
+		t.Run(c, func(t *testing.T) {
+			checkParserInvalid(t, "ParseAlterTableStatement", ParseAlterTableStatement, c)
+		})
+	}
 }
 
 func TestParse(t *testing.T) {
@@ -259,4 +978,11 @@ func TestParse(t *testing.T) {
 	if err == nil {
 		t.Fatalf("expected error, but got nil")
 	}
+	stmt, err := Parse("insert into foo values (1);")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := stmt.(*sql.InsertStatement); !ok {
+		t.Fatalf("got %T, want *sql.InsertStatement", stmt)
+	}
 }
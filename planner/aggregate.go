@@ -0,0 +1,156 @@
+package planner
+
+import (
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/sql"
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"strings"
+)
+
+var aggregateFunctions = map[string]query.AggregateFunction{
+	"count": query.AggregateCount,
+	"sum":   query.AggregateSum,
+	"avg":   query.AggregateAvg,
+	"min":   query.AggregateMin,
+	"max":   query.AggregateMax,
+}
+
+func isAggregateCall(e sql.Expression) bool {
+	call, ok := e.(*sql.FunctionCall)
+	if !ok {
+		return false
+	}
+	_, ok = aggregateFunctions[strings.ToLower(call.Name)]
+	return ok
+}
+
+// containsAggregate reports whether an aggregate function is called anywhere in the expression
+func containsAggregate(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *sql.FunctionCall:
+		if isAggregateCall(e) {
+			return true
+		}
+		for _, arg := range e.Arguments {
+			if containsAggregate(arg) {
+				return true
+			}
+		}
+	case *sql.BinaryOperation:
+		return containsAggregate(e.Left) || containsAggregate(e.Right)
+	case *sql.ArithmeticOperation:
+		return containsAggregate(e.Left) || containsAggregate(e.Right)
+	case *sql.And:
+		return containsAggregate(e.Left) || containsAggregate(e.Right)
+	case *sql.Or:
+		return containsAggregate(e.Left) || containsAggregate(e.Right)
+	case *sql.Not:
+		return containsAggregate(e.Expression)
+	case *sql.Negation:
+		return containsAggregate(e.Expression)
+	case *sql.IsNull:
+		return containsAggregate(e.Expression)
+	case *sql.AliasedExpression:
+		return containsAggregate(e.Expression)
+	}
+	return false
+}
+
+// aggregateScope converts the expressions evaluated after grouping. These may only reference
+// the rows of the input through a GROUP BY expression or the argument of an aggregate function,
+// and both are replaced by a reference to the matching column in the output of the aggregation.
+type aggregateScope struct {
+	schema types.TableSchema
+	groups []query.OutputColumn
+	calls  []query.AggregateCall
+}
+
+func newAggregateScope(groupBy []sql.Expression, schema types.TableSchema) (*aggregateScope, error) {
+	scope := &aggregateScope{schema: schema}
+	for _, e := range groupBy {
+		expr, name, err := ConvertExpression(e, schema)
+		if err != nil {
+			return nil, err
+		}
+		scope.groups = append(scope.groups, query.ComputedColumn(name, expr))
+	}
+	return scope, nil
+}
+
+func (s *aggregateScope) convert(e sql.Expression) (query.Expression, string, error) {
+	if isAggregateCall(e) {
+		return s.convertAggregate(e.(*sql.FunctionCall))
+	}
+	if !containsAggregate(e) {
+		expr, _, err := ConvertExpression(e, s.schema)
+		if err != nil {
+			return nil, "", err
+		}
+		for i, group := range s.groups {
+			if reflect.DeepEqual(group.Expression, expr) {
+				return query.NewColumnReference(i, expr.Type()), group.Name, nil
+			}
+		}
+		if column, ok := e.(sql.ColumnReference); ok {
+			return nil, "", fmt.Errorf("column %s must appear in the GROUP BY clause or be used in an aggregate function", columnName(column))
+		}
+	}
+	expr, err := convertOperation(e, func(operand sql.Expression) (query.Expression, error) {
+		expr, _, err := s.convert(operand)
+		return expr, err
+	})
+	return expr, "", err
+}
+
+func (s *aggregateScope) convertAggregate(e *sql.FunctionCall) (query.Expression, string, error) {
+	call := query.AggregateCall{
+		Function: aggregateFunctions[strings.ToLower(e.Name)],
+		Distinct: e.Distinct,
+	}
+	argument := "*"
+	if e.Star && call.Function != query.AggregateCount {
+		return nil, "", fmt.Errorf("%s(*) is not supported, only COUNT(*) is", e.Name)
+	}
+	if !e.Star {
+		if len(e.Arguments) != 1 {
+			return nil, "", fmt.Errorf("%s takes exactly one argument, got %d", e.Name, len(e.Arguments))
+		}
+		if containsAggregate(e.Arguments[0]) {
+			return nil, "", fmt.Errorf("aggregate function calls cannot be nested: %s", e)
+		}
+		expr, name, err := ConvertExpression(e.Arguments[0], s.schema)
+		if err != nil {
+			return nil, "", err
+		}
+		call.Argument = expr
+		argument = name
+	}
+	if argument != "" {
+		if call.Distinct {
+			argument = "distinct " + argument
+		}
+		call.Name = fmt.Sprintf("%s(%s)", call.Function, argument)
+	}
+
+	index := -1
+	for i, c := range s.calls {
+		if c.Function == call.Function && c.Distinct == call.Distinct && reflect.DeepEqual(c.Argument, call.Argument) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		index = len(s.calls)
+		s.calls = append(s.calls, call)
+	}
+	return query.NewColumnReference(len(s.groups)+index, call.Type()), call.Name, nil
+}
+
+func columnName(e sql.ColumnReference) string {
+	if e.Relation == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%s.%s", e.Relation, e.Name)
+}
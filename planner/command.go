@@ -0,0 +1,173 @@
+package planner
+
+import (
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/sql"
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// PlanCommand plans any statement other than SELECT, which is planned by Plan
+func PlanCommand(stmt sql.Statement, db *storage.Database) (query.Command, error) {
+	if table := targetTable(stmt); storage.IsStatisticsTable(table) {
+		return nil, fmt.Errorf("table %s is read-only", table)
+	}
+	switch stmt := stmt.(type) {
+	case *sql.InsertStatement:
+		return PlanInsert(stmt, db)
+	case *sql.UpdateStatement:
+		return PlanUpdate(stmt, db)
+	case *sql.DeleteStatement:
+		return PlanDelete(stmt, db)
+	case *sql.CreateTableStatement:
+		return PlanCreateTable(stmt)
+	case *sql.DropTableStatement:
+		return PlanDropTable(stmt)
+	case *sql.AlterTableStatement:
+		return PlanAlterTable(stmt)
+	case *sql.AnalyzeStatement:
+		return PlanAnalyze(stmt, db)
+	}
+	return nil, fmt.Errorf("plan:: not implemented: %T", stmt)
+}
+
+// targetTable returns the name of the table a statement changes, if any
+func targetTable(stmt sql.Statement) string {
+	switch stmt := stmt.(type) {
+	case *sql.InsertStatement:
+		return stmt.Table
+	case *sql.UpdateStatement:
+		return stmt.Table
+	case *sql.DeleteStatement:
+		return stmt.Table
+	case *sql.AlterTableStatement:
+		return stmt.Table
+	case *sql.AnalyzeStatement:
+		return stmt.Table
+	}
+	return ""
+}
+
+func PlanAnalyze(stmt *sql.AnalyzeStatement, db *storage.Database) (query.Command, error) {
+	if stmt.Table != "" {
+		if _, err := db.GetTable(stmt.Table); err != nil {
+			return nil, err
+		}
+	}
+	return query.NewAnalyze(stmt.Table), nil
+}
+
+func PlanInsert(stmt *sql.InsertStatement, db *storage.Database) (query.Command, error) {
+	table, err := db.GetTable(stmt.Table)
+	if err != nil {
+		return nil, err
+	}
+	schema := table.Schema
+
+	// without a column list the values are given for every column in order
+	columns := make([]int, len(schema.Columns))
+	for i := range columns {
+		columns[i] = i
+	}
+	if stmt.Columns != nil {
+		columns = make([]int, len(stmt.Columns))
+		for i, name := range stmt.Columns {
+			index, _, ok := schema.GetColumn(name)
+			if !ok {
+				return nil, fmt.Errorf("column %s not found in table %s", name, stmt.Table)
+			}
+			columns[i] = index
+		}
+	}
+
+	var source query.QueryPlan
+	if stmt.Query != nil {
+		source, err = Plan(stmt.Query, db)
+	} else {
+		source, err = convertValues(stmt.Values, schema, columns)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return query.NewInsert(stmt.Table, schema, columns, source)
+}
+
+// convertValues converts the rows of VALUES, which are typed after the columns they are stored in
+func convertValues(values [][]sql.Expression, schema types.TableSchema, columns []int) (query.QueryPlan, error) {
+	target := types.TableSchema{Columns: make([]types.ColumnSchema, len(columns))}
+	for i, index := range columns {
+		target.Columns[i] = schema.Columns[index]
+	}
+
+	rows := make([][]query.Expression, len(values))
+	for i, row := range values {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("INSERT has %d target columns but %d values", len(columns), len(row))
+		}
+		rows[i] = make([]query.Expression, len(row))
+		for j, e := range row {
+			converted, _, err := ConvertExpression(e, types.TableSchema{})
+			if err != nil {
+				return nil, err
+			}
+			rows[i][j], err = coerceLiteral(converted, target.Columns[j].Type)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return query.NewValues(target, rows)
+}
+
+func PlanUpdate(stmt *sql.UpdateStatement, db *storage.Database) (query.Command, error) {
+	table, err := db.GetTable(stmt.Table)
+	if err != nil {
+		return nil, err
+	}
+	// columns may be referenced with or without the table name, as in a SELECT from the table
+	schema := table.Schema.Prefix(stmt.Table)
+
+	assignments := make([]query.Assignment, len(stmt.Assignments))
+	for i, a := range stmt.Assignments {
+		index, _, ok := table.Schema.GetColumn(a.Column)
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in table %s", a.Column, stmt.Table)
+		}
+		value, _, err := ConvertExpression(a.Value, schema)
+		if err != nil {
+			return nil, err
+		}
+		value, err = coerceLiteral(value, schema.Columns[index].Type)
+		if err != nil {
+			return nil, err
+		}
+		assignments[i] = query.Assignment{Column: index, Expression: value}
+	}
+
+	var condition query.Expression
+	if stmt.Where != nil {
+		condition, err = convertCondition(stmt.Where, schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query.NewUpdate(stmt.Table, schema, assignments, condition)
+}
+
+func PlanDelete(stmt *sql.DeleteStatement, db *storage.Database) (query.Command, error) {
+	table, err := db.GetTable(stmt.Table)
+	if err != nil {
+		return nil, err
+	}
+	schema := table.Schema.Prefix(stmt.Table)
+
+	var condition query.Expression
+	if stmt.Where != nil {
+		condition, err = convertCondition(stmt.Where, schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return query.NewDelete(stmt.Table, schema, condition)
+}
@@ -0,0 +1,75 @@
+package planner
+
+import (
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/sql"
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+func PlanCreateTable(stmt *sql.CreateTableStatement) (query.Command, error) {
+	schema := types.TableSchema{Columns: make([]types.ColumnSchema, len(stmt.Columns))}
+	for i, definition := range stmt.Columns {
+		column, err := convertColumnDefinition(definition)
+		if err != nil {
+			return nil, err
+		}
+		schema.Columns[i] = column
+	}
+	return query.NewCreateTable(stmt.Name, schema, stmt.IfNotExists)
+}
+
+func PlanDropTable(stmt *sql.DropTableStatement) (query.Command, error) {
+	return query.NewDropTable(stmt.Name, stmt.IfExists), nil
+}
+
+// convertColumnDefinition resolves the type name and evaluates the default value, which must be a constant
+func convertColumnDefinition(definition sql.ColumnDefinition) (types.ColumnSchema, error) {
+	t, err := types.ParseType(definition.Type)
+	if err != nil {
+		return types.ColumnSchema{}, err
+	}
+	column := types.ColumnSchema{Name: definition.Name, Type: t, Nullable: !definition.NotNull}
+	if definition.Default == nil {
+		return column, nil
+	}
+
+	expr, _, err := ConvertExpression(definition.Default, types.TableSchema{})
+	if err != nil {
+		return types.ColumnSchema{}, fmt.Errorf("DEFAULT must be a constant: %w", err)
+	}
+	expr, err = coerceLiteral(expr, t)
+	if err != nil {
+		return types.ColumnSchema{}, err
+	}
+	if expr.Type() != t && expr.Type() != types.TypeNull {
+		return types.ColumnSchema{}, fmt.Errorf("mismatched types: column %s is of type %v, got default %s", definition.Name, t, definition.Default)
+	}
+	value, err := expr.Evaluate(&types.Row{})
+	if err != nil {
+		return types.ColumnSchema{}, fmt.Errorf("DEFAULT of column %s: %w", definition.Name, err)
+	}
+	// a NULL default is the same as no default
+	if !types.IsNull(value) {
+		column.Default = value
+	}
+	return column, nil
+}
+
+func PlanAlterTable(stmt *sql.AlterTableStatement) (query.Command, error) {
+	switch action := stmt.Action.(type) {
+	case sql.AddColumn:
+		column, err := convertColumnDefinition(action.Column)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewAddColumn(stmt.Table, column)
+	case sql.DropColumn:
+		return query.NewDropColumn(stmt.Table, action.Name), nil
+	case sql.RenameColumn:
+		return query.NewRenameColumn(stmt.Table, action.From, action.To), nil
+	case sql.RenameTable:
+		return query.NewRenameTable(stmt.Table, action.To), nil
+	}
+	return nil, fmt.Errorf("plan:: not implemented: %T", stmt.Action)
+}
@@ -10,19 +10,52 @@ import (
 )
 
 func ConvertExpression(input sql.Expression, schema types.TableSchema) (query.Expression, string, error) {
+	if e, ok := input.(sql.ColumnReference); ok {
+		return convertColumnReference(e, schema)
+	}
+	expr, err := convertOperation(input, func(operand sql.Expression) (query.Expression, error) {
+		expr, _, err := ConvertExpression(operand, schema)
+		return expr, err
+	})
+	return expr, "", err
+}
+
+// operandConverter converts the operands of an operation, which lets the same operator conversion
+// resolve column references either against a table schema or against the output of an aggregation
+type operandConverter func(sql.Expression) (query.Expression, error)
+
+// convertOperation converts literals and operators, converting their operands with convert
+func convertOperation(input sql.Expression, convert operandConverter) (query.Expression, error) {
 	switch e := input.(type) {
 	case sql.StringLiteral:
-		return query.NewConstant(types.NewText(e.Value)), "", nil
+		return query.NewConstant(types.NewText(e.Value)), nil
 	case sql.Boolean:
-		return query.NewConstant(types.NewBoolean(e.Value)), "", nil
+		return query.NewConstant(types.NewBoolean(e.Value)), nil
 	case sql.NumberLiteral:
-		return query.NewConstant(e.Value), "", nil
-	case sql.ColumnReference:
-		return convertColumnReference(e, schema)
+		return query.NewConstant(e.Value), nil
+	case sql.Null:
+		return query.NewConstant(types.NewNull(types.TypeNull)), nil
 	case *sql.BinaryOperation:
-		return convertBinaryOperation(*e, schema)
+		return convertBinaryOperation(*e, convert)
+	case *sql.And:
+		return convertLogicalOperation(e.Left, e.Right, query.NewAnd, convert)
+	case *sql.Or:
+		return convertLogicalOperation(e.Left, e.Right, query.NewOr, convert)
+	case *sql.Not:
+		return convertNot(*e, convert)
+	case *sql.IsNull:
+		return convertIsNull(*e, convert)
+	case *sql.ArithmeticOperation:
+		return convertArithmeticOperation(*e, convert)
+	case *sql.Negation:
+		return convertNegation(*e, convert)
+	case *sql.FunctionCall:
+		if _, ok := aggregateFunctions[strings.ToLower(e.Name)]; ok {
+			return nil, fmt.Errorf("aggregate function %s is not allowed here", e.Name)
+		}
+		return nil, fmt.Errorf("unknown function %s", e.Name)
 	}
-	return nil, "", fmt.Errorf("ConvertExpression:: not implemented: %T", input)
+	return nil, fmt.Errorf("ConvertExpression:: not implemented: %T", input)
 }
 
 func convertColumnReference(e sql.ColumnReference, schema types.TableSchema) (query.Expression, string, error) {
@@ -41,37 +74,183 @@ func convertColumnReference(e sql.ColumnReference, schema types.TableSchema) (qu
 	return query.NewColumnReference(index, t), name, nil
 }
 
-func ConvertBinaryOperator(input lexer.BinaryOperator) query.BinaryOperator {
+func ConvertBinaryOperator(input lexer.BinaryOperator) (query.BinaryOperator, error) {
 	switch input {
 	case lexer.BinaryOperatorEq:
-		return query.BinaryOperatorEq
+		return query.BinaryOperatorEq, nil
 	case lexer.BinaryOperatorNotEq:
-		return query.BinaryOperatorNe
+		return query.BinaryOperatorNe, nil
 	case lexer.BinaryOperatorGt:
-		return query.BinaryOperatorGt
+		return query.BinaryOperatorGt, nil
 	case lexer.BinaryOperatorGte:
-		return query.BinaryOperatorGe
+		return query.BinaryOperatorGe, nil
 	case lexer.BinaryOperatorLt:
-		return query.BinaryOperatorLt
+		return query.BinaryOperatorLt, nil
 	case lexer.BinaryOperatorLte:
-		return query.BinaryOperatorLe
+		return query.BinaryOperatorLe, nil
 	}
 
-	panic(fmt.Errorf("ConvertBinaryOperator:: not implemented: %v", input))
+	return 0, fmt.Errorf("unsupported comparison operator %v", input)
 }
 
-func convertBinaryOperation(input sql.BinaryOperation, schema types.TableSchema) (*query.BinaryOperation, string, error) {
-	left, _, err := ConvertExpression(input.Left, schema)
+func convertBinaryOperation(input sql.BinaryOperation, convert operandConverter) (query.Expression, error) {
+	left, err := convert(input.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := convert(input.Right)
+	if err != nil {
+		return nil, err
+	}
+	left, right, err = coerceLiterals(left, right)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
-	right, _, err := ConvertExpression(input.Right, schema)
+	operator, err := ConvertBinaryOperator(input.Operator)
 	if err != nil {
-		return nil, "", err
+		return nil, fmt.Errorf("%w: %s", err, input)
 	}
-	operator := ConvertBinaryOperator(input.Operator)
 	expr, err := query.NewBinaryOperation(left, right, operator)
-	return expr, "", err
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func ConvertArithmeticOperator(input lexer.ArithmeticOperator) (query.ArithmeticOperator, error) {
+	switch input {
+	case lexer.ArithmeticOperatorAdd:
+		return query.ArithmeticOperatorAdd, nil
+	case lexer.ArithmeticOperatorSub:
+		return query.ArithmeticOperatorSub, nil
+	case lexer.ArithmeticOperatorMul:
+		return query.ArithmeticOperatorMul, nil
+	case lexer.ArithmeticOperatorDiv:
+		return query.ArithmeticOperatorDiv, nil
+	case lexer.ArithmeticOperatorMod:
+		return query.ArithmeticOperatorMod, nil
+	}
+
+	return 0, fmt.Errorf("unsupported arithmetic operator %v", input)
+}
+
+func convertArithmeticOperation(input sql.ArithmeticOperation, convert operandConverter) (query.Expression, error) {
+	left, err := convert(input.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := convert(input.Right)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := ConvertArithmeticOperator(input.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, input)
+	}
+	expr, err := query.NewArithmeticOperation(retypeNull(left, types.TypeDecimal), retypeNull(right, types.TypeDecimal), operator)
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func convertNegation(input sql.Negation, convert operandConverter) (query.Expression, error) {
+	operand, err := convert(input.Expression)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := query.NewNegation(retypeNull(operand, types.TypeDecimal))
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func convertLogicalOperation[T query.Expression](
+	left, right sql.Expression,
+	constructor func(left, right query.Expression) (T, error),
+	convert operandConverter,
+) (query.Expression, error) {
+	l, err := convert(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := convert(right)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := constructor(retypeNull(l, types.TypeBoolean), retypeNull(r, types.TypeBoolean))
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func convertNot(input sql.Not, convert operandConverter) (query.Expression, error) {
+	operand, err := convert(input.Expression)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := query.NewNot(retypeNull(operand, types.TypeBoolean))
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func convertIsNull(input sql.IsNull, convert operandConverter) (query.Expression, error) {
+	operand, err := convert(input.Expression)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewIsNull(operand, input.Not), nil
+}
+
+// coerceLiterals gives untyped NULL literals the type of the other operand, and converts a text constant
+// compared against a date into a date constant, since SQL has no dedicated date literal syntax in this dialect.
+func coerceLiterals(left, right query.Expression) (query.Expression, query.Expression, error) {
+	left, right = retypeNull(left, right.Type()), retypeNull(right, left.Type())
+
+	var err error
+	if left.Type() == types.TypeDate {
+		right, err = textToDate(right)
+	} else if right.Type() == types.TypeDate {
+		left, err = textToDate(left)
+	}
+	return left, right, err
+}
+
+// coerceLiteral converts a literal stored in a column of type t, as coerceLiterals does for comparisons
+func coerceLiteral(e query.Expression, t types.Type) (query.Expression, error) {
+	e = retypeNull(e, t)
+	if t == types.TypeDate {
+		return textToDate(e)
+	}
+	return e, nil
+}
+
+// retypeNull converts an untyped NULL constant into a NULL of the given type, other expressions are unchanged
+func retypeNull(e query.Expression, t types.Type) query.Expression {
+	if c, ok := e.(query.Constant); ok && c.Type() == types.TypeNull {
+		return query.NewConstant(types.NewNull(t))
+	}
+	return e
+}
+
+func textToDate(e query.Expression) (query.Expression, error) {
+	c, ok := e.(query.Constant)
+	if !ok || c.Type() != types.TypeText {
+		return e, nil
+	}
+	text, ok := c.Value.(types.Text)
+	if !ok {
+		return e, nil
+	}
+	date, err := types.ParseDate(text.Value())
+	if err != nil {
+		return nil, err
+	}
+	return query.NewConstant(date), nil
 }
 
 func FindColumnIndex(name string, schema types.TableSchema) (int, string, error) {
@@ -82,7 +261,7 @@ func FindColumnIndex(name string, schema types.TableSchema) (int, string, error)
 	for i, column := range schema.Columns {
 		if strings.HasSuffix(column.Name, suffix) {
 			if found {
-				return 0, "", fmt.Errorf("unkown column name: %s", name)
+				return 0, "", fmt.Errorf("ambiguous column name: %s", name)
 			}
 			index = i
 			resName = column.Name
@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"github.com/Vignesh-Rajarajan/go-db/lexer"
 	"github.com/Vignesh-Rajarajan/go-db/sql"
 	"github.com/Vignesh-Rajarajan/go-db/sql/query"
 	"github.com/Vignesh-Rajarajan/go-db/storage"
@@ -75,3 +76,75 @@ func TestFindColumnIndex(t *testing.T) {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+func TestConvertLogicalExpression(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	schema := sampleData.Films.Schema.Prefix("films")
+
+	director := &query.BinaryOperation{
+		Left:     query.NewColumnReference(2, types.TypeDecimal),
+		Right:    query.NewConstant(types.NewDecimal("1")),
+		Operator: query.BinaryOperatorEq,
+	}
+	title := &query.BinaryOperation{
+		Left:     query.NewColumnReference(1, types.TypeText),
+		Right:    query.NewConstant(types.NewText("Alien")),
+		Operator: query.BinaryOperatorNe,
+	}
+	directorSQL := &sql.BinaryOperation{
+		Left:     sql.ColumnReference{Name: "director"},
+		Operator: lexer.BinaryOperatorEq,
+		Right:    sql.NumberLiteral{Value: types.NewDecimal("1")},
+	}
+	titleSQL := &sql.BinaryOperation{
+		Left:     sql.ColumnReference{Name: "title"},
+		Operator: lexer.BinaryOperatorNotEq,
+		Right:    sql.StringLiteral{Value: "Alien"},
+	}
+
+	cases := []struct {
+		input sql.Expression
+		want  query.Expression
+	}{
+		{
+			input: &sql.And{Left: directorSQL, Right: titleSQL},
+			want:  &query.And{Left: director, Right: title},
+		},
+		{
+			input: &sql.Or{Left: directorSQL, Right: titleSQL},
+			want:  &query.Or{Left: director, Right: title},
+		},
+		{
+			input: &sql.Not{Expression: directorSQL},
+			want:  &query.Not{Expression: director},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.input.String(), func(t *testing.T) {
+			got, _, err := ConvertExpression(c.input, schema)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Expression for \n%v\n is \ngot %v, want %v\n", c.input, got, c.want)
+			}
+		})
+	}
+
+	_, _, err := ConvertExpression(&sql.And{Left: directorSQL, Right: sql.ColumnReference{Name: "title"}}, schema)
+	if err == nil {
+		t.Errorf("expected error for non-boolean operand, got nil")
+	}
+}
+
+func TestConvertUnknownOperator(t *testing.T) {
+	schema := storage.GetSampleData().Films.Schema.Prefix("films")
+	comparison := &sql.BinaryOperation{Left: sql.ColumnReference{Name: "id"}, Operator: lexer.BinaryOperator(-1), Right: sql.NumberLiteral{Value: types.NewDecimal("1")}}
+	if _, _, err := ConvertExpression(comparison, schema); err == nil {
+		t.Errorf("ConvertExpression(%s) returned nil, want error", comparison)
+	}
+	arithmetic := &sql.ArithmeticOperation{Left: sql.ColumnReference{Name: "id"}, Operator: lexer.ArithmeticOperator(-1), Right: sql.NumberLiteral{Value: types.NewDecimal("1")}}
+	if _, _, err := ConvertExpression(arithmetic, schema); err == nil {
+		t.Errorf("ConvertExpression(%s) returned nil, want error", arithmetic)
+	}
+}
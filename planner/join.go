@@ -0,0 +1,151 @@
+package planner
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"reflect"
+	"slices"
+)
+
+// planJoin picks a merge join or a hash join when the condition requires columns of the left and right inputs to be equal,
+// any other condition is evaluated by a nested loop join. A merge join needs both inputs in key order, see ordering. No SQL
+// statement produces such inputs yet, as FROM only lists tables, which are read in insertion order. Merge joins are only
+// chosen for plans built with the query package whose inputs are sorted, and keep being chosen when the plan is rewritten.
+func planJoin(t query.JoinType, left, right query.QueryPlan, condition query.Expression, db *storage.Database) (query.QueryPlan, error) {
+	width := len(left.Schema().Columns)
+	var leftKeys, rightKeys []query.Expression
+	var rest []query.Expression
+	for _, e := range conjuncts(condition) {
+		if l, r, ok := equiJoinKeys(e, width); ok {
+			leftKeys = append(leftKeys, l)
+			rightKeys = append(rightKeys, r)
+			continue
+		}
+		rest = append(rest, e)
+	}
+	residual := conjunction(rest)
+	if len(leftKeys) == 0 {
+		return query.NewJoin(t, left, right, condition)
+	}
+	// inputs that are already in key order are merged without keeping either of them in memory
+	if sortedOn(left, leftKeys) && sortedOn(right, rightKeys) {
+		return query.NewMergeJoin(t, left, right, leftKeys, rightKeys, residual)
+	}
+	buildLeft := estimateRows(left, db) < estimateRows(right, db)
+	return query.NewHashJoin(t, left, right, leftKeys, rightKeys, residual, buildLeft)
+}
+
+// conjuncts splits a condition on its top level ANDs
+func conjuncts(e query.Expression) []query.Expression {
+	if and, ok := e.(*query.And); ok {
+		return append(conjuncts(and.Left), conjuncts(and.Right)...)
+	}
+	return []query.Expression{e}
+}
+
+// conjunction joins conditions with AND, it returns nil when there is none
+func conjunction(conditions []query.Expression) query.Expression {
+	var result query.Expression
+	for _, e := range conditions {
+		if result == nil {
+			result = e
+		} else {
+			result = &query.And{Left: result, Right: e}
+		}
+	}
+	return result
+}
+
+// equiJoinKeys matches an equality between a column of the left input and a column of the right input, whose
+// columns start at width in the combined schema. The right key is returned relative to the right input.
+func equiJoinKeys(e query.Expression, width int) (left, right query.Expression, ok bool) {
+	b, ok := e.(*query.BinaryOperation)
+	if !ok || b.Operator != query.BinaryOperatorEq {
+		return nil, nil, false
+	}
+	l, lok := b.Left.(query.ColumnReference)
+	r, rok := b.Right.(query.ColumnReference)
+	if !lok || !rok || l.T != r.T || !l.T.Comparable() {
+		return nil, nil, false
+	}
+	if l.Index >= width {
+		l, r = r, l
+	}
+	if l.Index >= width || r.Index < width {
+		return nil, nil, false
+	}
+	return l, query.NewColumnReference(r.Index-width, r.T), true
+}
+
+// estimateRows guesses the number of rows produced by a join input, it is only used to pick the build side
+func estimateRows(plan query.QueryPlan, db *storage.Database) int {
+	switch p := plan.(type) {
+	case *query.Load:
+		table, err := db.GetTable(p.TableName)
+		if err != nil {
+			return 0
+		}
+		return len(table.Rows)
+	case *query.Select:
+		// without statistics every row is assumed to be kept
+		return estimateRows(p.From, db)
+	case *query.Project:
+		return estimateRows(p.From, db)
+	case *query.Join:
+		return max(estimateRows(p.Left, db), estimateRows(p.Right, db))
+	case *query.HashJoin:
+		return max(estimateRows(p.Left, db), estimateRows(p.Right, db))
+	case *query.MergeJoin:
+		return max(estimateRows(p.Left, db), estimateRows(p.Right, db))
+	}
+	return 0
+}
+
+// ordering returns the keys the rows of a plan are known to be sorted by, if any
+func ordering(plan query.QueryPlan) []query.SortKey {
+	switch p := plan.(type) {
+	case *query.Sort:
+		return p.Keys
+	case *query.TopN:
+		return p.Keys
+	case *query.Select:
+		return ordering(p.From)
+	case *query.Limit:
+		return ordering(p.From)
+	case *query.Project:
+		// the order holds for the leading keys computed by an output column
+		var keys []query.SortKey
+		for _, key := range ordering(p.From) {
+			i := slices.IndexFunc(p.Columns, func(c query.OutputColumn) bool {
+				return reflect.DeepEqual(c.Expression, key.Expression)
+			})
+			if i < 0 {
+				break
+			}
+			key.Expression = query.NewColumnReference(i, key.Expression.Type())
+			keys = append(keys, key)
+		}
+		return keys
+	case *query.MergeJoin:
+		// the left columns come first in the combined rows and the left input is read in order,
+		// unless unmatched right rows padded with NULLs are interleaved with it
+		if p.Type == query.JoinTypeInner || p.Type == query.JoinTypeLeftOuter {
+			return ordering(p.Left)
+		}
+	}
+	return nil
+}
+
+// sortedOn reports whether a plan is sorted in ascending order of the join keys, in the same order
+func sortedOn(plan query.QueryPlan, keys []query.Expression) bool {
+	order := ordering(plan)
+	if len(order) < len(keys) {
+		return false
+	}
+	for i, key := range keys {
+		if order[i].Descending || !reflect.DeepEqual(order[i].Expression, key) {
+			return false
+		}
+	}
+	return true
+}
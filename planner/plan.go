@@ -5,36 +5,273 @@ import (
 	"github.com/Vignesh-Rajarajan/go-db/sql"
 	"github.com/Vignesh-Rajarajan/go-db/sql/query"
 	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
 )
 
+// expressionConverter resolves an expression of the select list, HAVING or ORDER BY clause
+// and returns it along with the name of the column it produces
+type expressionConverter func(sql.Expression) (query.Expression, string, error)
+
+// PlanExplain plans the query of an EXPLAIN statement and wraps it in a query.Explain
+func PlanExplain(stmt *sql.ExplainStatement, db *storage.Database) (*query.Explain, error) {
+	plan, err := Plan(stmt.Query, db)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewExplain(plan, stmt.Analyze), nil
+}
+
 func Plan(stmt *sql.SelectStatement, db *storage.Database) (query.QueryPlan, error) {
 	plan, err := convertTableReference(stmt.From, db)
 	if err != nil {
 		return nil, err
 	}
+	if stmt.Where != nil {
+		plan, err = convertWhere(stmt.Where, plan)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	schema := plan.Schema()
+	convert := func(e sql.Expression) (query.Expression, string, error) {
+		return ConvertExpression(e, schema)
+	}
+	var scope *aggregateScope
+	if isAggregation(stmt) {
+		if _, ok := stmt.What.(sql.Star); ok {
+			return nil, fmt.Errorf("SELECT * cannot be used with GROUP BY or aggregate functions")
+		}
+		scope, err = newAggregateScope(stmt.GroupBy, schema)
+		if err != nil {
+			return nil, err
+		}
+		convert = scope.convert
+	}
+
+	// columns stays nil for SELECT *, which needs no projection
+	var columns []query.OutputColumn
 	switch what := stmt.What.(type) {
 	case sql.Star:
 	// Do nothing
 	case sql.ExpressionList:
-		schema := plan.Schema()
-		columns := make([]query.OutputColumn, len(what.Expressions))
+		columns = make([]query.OutputColumn, len(what.Expressions))
 		for i, e := range what.Expressions {
-			converted, name, err := ConvertExpression(e, schema)
+			alias := ""
+			if aliased, ok := e.(*sql.AliasedExpression); ok {
+				e, alias = aliased.Expression, aliased.Alias
+			}
+			converted, name, err := convert(e)
 			if err != nil {
 				return nil, err
 			}
+			if alias != "" {
+				name = alias
+			}
+			// computed columns without an alias are named after their position, as output names must be distinct
+			if name == "" {
+				name = fmt.Sprintf("?column?%d", i+1)
+			}
 			columns[i].Expression = converted
 			columns[i].Name = name
 		}
+	default:
+		return nil, fmt.Errorf("plan:: not implemented: %T", what)
+	}
+
+	// HAVING and ORDER BY are converted before the aggregation is planned, as they may add aggregates of their own
+	var having query.Expression
+	if stmt.Having != nil {
+		having, _, err = convert(stmt.Having)
+		if err != nil {
+			return nil, err
+		}
+		if having.Type() != types.TypeBoolean {
+			return nil, fmt.Errorf("HAVING clause must be a boolean expression, got %v: %s", having.Type(), stmt.Having)
+		}
+	}
+	var keys []query.SortKey
+	if len(stmt.OrderBy) > 0 {
+		keys, err = convertOrderBy(stmt.OrderBy, schema, convert, columns)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if scope != nil {
+		plan, err = query.NewAggregate(plan, scope.groups, scope.calls)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if having != nil {
+		plan, err = query.NewSelect(plan, having)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if keys != nil {
+		plan, err = query.NewSort(plan, keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if stmt.Limit != nil || stmt.Offset != nil {
+		plan, err = convertLimit(stmt.Limit, stmt.Offset, plan)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if columns != nil {
 		plan, err = query.NewProject(plan, columns)
 		if err != nil {
 			return nil, err
+		}
+	}
+	plan, err = rewrite(plan, db)
+	if err != nil {
+		return nil, err
+	}
+	return pruneColumns(plan, db)
+}
+
+// isAggregation reports whether the rows are grouped, which is also the case when aggregate
+// functions are used without GROUP BY, as the whole input then forms a single group
+func isAggregation(stmt *sql.SelectStatement) bool {
+	if len(stmt.GroupBy) > 0 || stmt.Having != nil {
+		return true
+	}
+	if what, ok := stmt.What.(sql.ExpressionList); ok {
+		for _, e := range what.Expressions {
+			if containsAggregate(e) {
+				return true
+			}
+		}
+	}
+	for _, term := range stmt.OrderBy {
+		if containsAggregate(term.Expression) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertWhere resolves the WHERE predicate against the schema produced by the FROM clause,
+// which is the prefixed table schema for a single table and the combined schema for a join.
+func convertWhere(where sql.Expression, from query.QueryPlan) (query.QueryPlan, error) {
+	condition, err := convertCondition(where, from.Schema())
+	if err != nil {
+		return nil, err
+	}
+	return query.NewSelect(from, condition)
+}
+
+func convertCondition(where sql.Expression, schema types.TableSchema) (query.Expression, error) {
+	condition, _, err := ConvertExpression(where, schema)
+	if err != nil {
+		return nil, err
+	}
+	if condition.Type() != types.TypeBoolean {
+		return nil, fmt.Errorf("WHERE clause must be a boolean expression, got %v: %s", condition.Type(), where)
+	}
+	return condition, nil
+}
+
+// convertOrderBy converts the sort keys, which are applied before the rows are projected. Keys naming an output column or
+// giving its ordinal position are replaced by the select list expression, which is evaluated against the same input.
+func convertOrderBy(terms []sql.OrderingTerm, schema types.TableSchema, convert expressionConverter, columns []query.OutputColumn) ([]query.SortKey, error) {
+	keys := make([]query.SortKey, len(terms))
+	for i, term := range terms {
+		expr, err := convertOrderingExpression(term.Expression, schema, convert, columns)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = query.SortKey{
+			Expression: expr,
+			Descending: term.Descending,
+			// NULLs sort as larger than any value unless specified otherwise
+			NullsFirst: term.Nulls == sql.NullsFirst || term.Nulls == sql.NullsDefault && term.Descending,
+		}
+	}
+	return keys, nil
+}
 
+func convertOrderingExpression(e sql.Expression, schema types.TableSchema, convert expressionConverter, columns []query.OutputColumn) (query.Expression, error) {
+	switch e := e.(type) {
+	case sql.NumberLiteral:
+		position, ok := e.Value.Int()
+		count := len(columns)
+		if columns == nil {
+			count = len(schema.Columns)
 		}
-	default:
-		return nil, fmt.Errorf("plan:: not implemented: %T", what)
+		if !ok || position < 1 || position > count {
+			return nil, fmt.Errorf("ORDER BY position %v is not in select list", e.Value)
+		}
+		if columns == nil {
+			column := schema.Columns[position-1]
+			return query.NewColumnReference(position-1, column.Type), nil
+		}
+		return columns[position-1].Expression, nil
+	case sql.ColumnReference:
+		if e.Relation == "" {
+			for _, column := range columns {
+				if column.Name == e.Name {
+					return column.Expression, nil
+				}
+			}
+		}
+	}
+	expr, _, err := convert(e)
+	return expr, err
+}
+
+// convertLimit applies LIMIT and OFFSET, turning a Sort directly below into a TopN
+// so that only the rows that are returned are kept in memory.
+func convertLimit(limit, offset sql.Expression, from query.QueryPlan) (query.QueryPlan, error) {
+	count := -1
+	if limit != nil {
+		n, err := constantInt(limit, "LIMIT")
+		if err != nil {
+			return nil, err
+		}
+		count = n
+	}
+	skip := 0
+	if offset != nil {
+		n, err := constantInt(offset, "OFFSET")
+		if err != nil {
+			return nil, err
+		}
+		skip = n
+	}
+
+	if sorted, ok := from.(*query.Sort); ok && count >= 0 {
+		return query.NewTopN(sorted.From, sorted.Keys, skip, count)
+	}
+	return query.NewLimit(from, skip, count)
+}
+
+// constantInt evaluates an expression that may not reference any column to a non-negative integer
+func constantInt(e sql.Expression, clause string) (int, error) {
+	converted, _, err := ConvertExpression(e, types.TableSchema{})
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a constant: %w", clause, err)
+	}
+	result, err := converted.Evaluate(&types.Row{})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", clause, err)
 	}
-	return plan, nil
+	value, ok := result.(types.Decimal)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a number, got %s", clause, e)
+	}
+	n, ok := value.Int()
+	if !ok || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %v", clause, value)
+	}
+	return n, nil
 }
 
 func convertTableReference(ref sql.TableReference, db *storage.Database) (query.QueryPlan, error) {
@@ -44,6 +281,9 @@ func convertTableReference(ref sql.TableReference, db *storage.Database) (query.
 		if err != nil {
 			return nil, err
 		}
+		if f.Alias != "" {
+			return query.NewAliasedLoad(f.Name, f.Alias, table.Schema), nil
+		}
 		return query.NewLoad(f.Name, table.Schema), nil
 	case *sql.Join:
 		joinType := convertJoinType(f.Type)
@@ -61,7 +301,7 @@ func convertTableReference(ref sql.TableReference, db *storage.Database) (query.
 		if err != nil {
 			return nil, err
 		}
-		return query.NewJoin(joinType, left, right, condition)
+		return planJoin(joinType, left, right, condition, db)
 	}
 
 	return nil, fmt.Errorf("plan:: not implemented: %T", ref)
@@ -75,6 +315,8 @@ func convertJoinType(t sql.JoinType) query.JoinType {
 		return query.JoinTypeLeftOuter
 	case sql.JoinTypeRight:
 		return query.JoinTypeRightOuter
+	case sql.JoinTypeFull:
+		return query.JoinTypeFullOuter
 	}
 	return query.JoinTypeInner
 }
@@ -1,6 +1,8 @@
 package planner
 
 import (
+	"context"
+	"fmt"
 	"github.com/Vignesh-Rajarajan/go-db/parser"
 	"github.com/Vignesh-Rajarajan/go-db/sql"
 	"github.com/Vignesh-Rajarajan/go-db/sql/query"
@@ -27,14 +29,77 @@ func parse(t *testing.T, stmt string) *sql.SelectStatement {
 func TestPlan(t *testing.T) {
 	sampleData := storage.GetSampleData()
 
-	join, err := query.NewJoin(
+	// the inputs of a join are narrowed to the columns read above it
+	narrow := func(from query.QueryPlan, columns ...query.OutputColumn) query.QueryPlan {
+		project, err := query.NewProject(from, columns)
+		if err != nil {
+			t.Fatalf("unexpected error while creating projection : %v", err)
+		}
+		return project
+	}
+	join, err := query.NewHashJoin(
+		query.JoinTypeInner,
+		narrow(query.NewLoad("films", sampleData.Films.Schema),
+			query.SimpleColumn("films.id", 0, types.TypeDecimal),
+			query.SimpleColumn("films.director", 2, types.TypeDecimal)),
+		narrow(query.NewLoad("people", sampleData.People.Schema), query.SimpleColumn("people.id", 0, types.TypeDecimal)),
+		[]query.Expression{query.ColumnReference{Index: 1, T: types.TypeDecimal}},
+		[]query.Expression{query.ColumnReference{Index: 0, T: types.TypeDecimal}},
+		nil,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error while creating join : %v", err)
+	}
+	// the condition on films alone is evaluated before the join
+	residualJoin, err := query.NewHashJoin(
+		query.JoinTypeLeftOuter,
+		query.NewLoad("people", sampleData.People.Schema),
+		&query.Select{
+			From: query.NewLoad("films", sampleData.Films.Schema),
+			Condition: &query.BinaryOperation{
+				Left:     query.ColumnReference{Index: 3, T: types.TypeDate},
+				Right:    query.NewConstant(types.NewDate(2000, 1, 1)),
+				Operator: query.BinaryOperatorGt,
+			},
+		},
+		[]query.Expression{query.ColumnReference{Index: 0, T: types.TypeDecimal}},
+		[]query.Expression{query.ColumnReference{Index: 2, T: types.TypeDecimal}},
+		nil,
+		true,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error while creating join : %v", err)
+	}
+	filteredJoin, err := query.NewHashJoin(
+		query.JoinTypeInner,
+		narrow(query.NewLoad("films", sampleData.Films.Schema),
+			query.SimpleColumn("films.title", 1, types.TypeText),
+			query.SimpleColumn("films.director", 2, types.TypeDecimal)),
+		narrow(&query.Select{
+			From: query.NewLoad("people", sampleData.People.Schema),
+			Condition: &query.BinaryOperation{
+				Left:     query.ColumnReference{Index: 1, T: types.TypeText},
+				Right:    query.NewConstant(types.NewText("Frank Darabont")),
+				Operator: query.BinaryOperatorEq,
+			},
+		}, query.SimpleColumn("people.id", 0, types.TypeDecimal)),
+		[]query.Expression{query.ColumnReference{Index: 1, T: types.TypeDecimal}},
+		[]query.Expression{query.ColumnReference{Index: 0, T: types.TypeDecimal}},
+		nil,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error while creating join : %v", err)
+	}
+	loopJoin, err := query.NewJoin(
 		query.JoinTypeInner,
 		query.NewLoad("films", sampleData.Films.Schema),
 		query.NewLoad("people", sampleData.People.Schema),
 		&query.BinaryOperation{
 			Left:     query.ColumnReference{Index: 2, T: types.TypeDecimal},
 			Right:    query.ColumnReference{Index: 4, T: types.TypeDecimal},
-			Operator: query.BinaryOperatorEq,
+			Operator: query.BinaryOperatorGt,
 		},
 	)
 	if err != nil {
@@ -62,22 +127,105 @@ func TestPlan(t *testing.T) {
 				},
 				Columns: []query.OutputColumn{
 					{
-						"films.id",
-						query.ColumnReference{Index: 0, T: types.TypeDecimal},
+						Name:       "films.id",
+						Expression: query.ColumnReference{Index: 0, T: types.TypeDecimal},
 					},
 				},
 			},
 		},
 		{
-			stmt: "SELECT films.id, people.id FROM films JOIN people ON films.id = people.id",
+			stmt: "SELECT films.id, people.id FROM films JOIN people ON films.director = people.id",
 			want: &query.Project{
 				From: join,
 				Columns: []query.OutputColumn{
-					{"films.id", query.ColumnReference{Index: 0, T: types.TypeDecimal}},
-					{"people.id", query.ColumnReference{Index: 4, T: types.TypeDecimal}},
+					{Name: "films.id", Expression: query.ColumnReference{Index: 0, T: types.TypeDecimal}},
+					{Name: "people.id", Expression: query.ColumnReference{Index: 2, T: types.TypeDecimal}},
 				},
 			},
 		},
+		{
+			stmt: "SELECT title FROM films JOIN people ON films.director = people.id WHERE name = 'Frank Darabont'",
+			want: &query.Project{
+				From: filteredJoin,
+				Columns: []query.OutputColumn{
+					{Name: "films.title", Expression: query.ColumnReference{Index: 0, T: types.TypeText}},
+				},
+			},
+		},
+		{
+			stmt: "SELECT * FROM people LEFT JOIN films ON films.release_date > '2000-01-01' AND films.director = people.id",
+			want: residualJoin,
+		},
+		{
+			stmt: "SELECT * FROM films JOIN people ON films.director > people.id",
+			want: loopJoin,
+		},
+		{
+			stmt: "SELECT * FROM films WHERE release_date > '2000-01-01'",
+			want: &query.Select{
+				From: query.NewLoad("films", sampleData.Films.Schema),
+				Condition: &query.BinaryOperation{
+					Left:     query.ColumnReference{Index: 3, T: types.TypeDate},
+					Right:    query.NewConstant(types.NewDate(2000, 1, 1)),
+					Operator: query.BinaryOperatorGt,
+				},
+			},
+		},
+		{
+			stmt: "SELECT title FROM films ORDER BY 1 DESC, release_date",
+			want: &query.Project{
+				From: &query.Sort{
+					From: query.NewLoad("films", sampleData.Films.Schema),
+					Keys: []query.SortKey{
+						{Expression: query.ColumnReference{Index: 1, T: types.TypeText}, Descending: true, NullsFirst: true},
+						{Expression: query.ColumnReference{Index: 3, T: types.TypeDate}},
+					},
+				},
+				Columns: []query.OutputColumn{
+					{Name: "films.title", Expression: query.ColumnReference{Index: 1, T: types.TypeText}},
+				},
+			},
+		},
+		{
+			stmt: "SELECT * FROM films ORDER BY 2 NULLS FIRST",
+			want: &query.Sort{
+				From: query.NewLoad("films", sampleData.Films.Schema),
+				Keys: []query.SortKey{
+					{Expression: query.ColumnReference{Index: 1, T: types.TypeText}, NullsFirst: true},
+				},
+			},
+		},
+		{
+			stmt: "SELECT * FROM films ORDER BY 2 LIMIT 2 OFFSET 1",
+			want: &query.TopN{
+				From: query.NewLoad("films", sampleData.Films.Schema),
+				Keys: []query.SortKey{
+					{Expression: query.ColumnReference{Index: 1, T: types.TypeText}},
+				},
+				Offset: 1,
+				Count:  2,
+			},
+		},
+		{
+			stmt: "SELECT * FROM films ORDER BY 2 OFFSET 1",
+			want: &query.Limit{
+				From: &query.Sort{
+					From: query.NewLoad("films", sampleData.Films.Schema),
+					Keys: []query.SortKey{
+						{Expression: query.ColumnReference{Index: 1, T: types.TypeText}},
+					},
+				},
+				Offset: 1,
+				Count:  -1,
+			},
+		},
+		{
+			stmt: "SELECT * FROM films FETCH FIRST 1 + 1 ROWS ONLY",
+			want: &query.Limit{
+				From:  query.NewLoad("films", sampleData.Films.Schema),
+				Count: 2,
+			},
+		},
 		//{
 		//	stmt: "SELECT id, title, release_date, director FROM films",
 		//	want: &query.Project{
@@ -117,3 +265,278 @@ func TestPlan(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanInvalidWhere(t *testing.T) {
+	sampleData := storage.GetSampleData()
+
+	cases := []string{
+		"SELECT * FROM films WHERE title",
+		"SELECT * FROM films WHERE name = 'Frank Darabont'",
+		"SELECT * FROM films JOIN people ON films.director = people.id WHERE id = 1",
+		"SELECT * FROM films WHERE release_date = 'yesterday'",
+		"SELECT title FROM films ORDER BY 2",
+		"SELECT title FROM films ORDER BY 0",
+		"SELECT * FROM films ORDER BY 1.5",
+		"SELECT * FROM films ORDER BY name",
+		"SELECT * FROM films LIMIT -1",
+		"SELECT * FROM films LIMIT 1.5",
+		"SELECT * FROM films LIMIT id",
+		"SELECT * FROM films OFFSET 'one'",
+		"SELECT title FROM films a JOIN films b ON a.id = b.id",
+		"SELECT films.title FROM films f",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			stmt := parse(t, c)
+			_, err := Plan(stmt, sampleData.Database)
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPlanInvalidAggregate(t *testing.T) {
+	sampleData := storage.GetSampleData()
+
+	cases := []string{
+		"SELECT title, count(*) FROM films",
+		"SELECT title FROM films GROUP BY director",
+		"SELECT * FROM films GROUP BY director",
+		"SELECT director FROM films GROUP BY director ORDER BY title",
+		"SELECT director FROM films GROUP BY director HAVING id > 1",
+		"SELECT director FROM films GROUP BY director HAVING count(*)",
+		"SELECT count(*) FROM films WHERE count(*) > 1",
+		"SELECT director FROM films GROUP BY count(*)",
+		"SELECT sum(count(*)) FROM films",
+		"SELECT sum(title) FROM films",
+		"SELECT min(*) FROM films",
+		"SELECT max(id, title) FROM films",
+		"SELECT lower(title) FROM films",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			stmt := parse(t, c)
+			_, err := Plan(stmt, sampleData.Database)
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPlanInsertInvalid(t *testing.T) {
+	sampleData := storage.GetSampleData()
+
+	cases := []string{
+		"INSERT INTO missing VALUES (1)",
+		"INSERT INTO people VALUES (1)",
+		"INSERT INTO people VALUES ('Frank Darabont', 1)",
+		"INSERT INTO people (id) VALUES (3)",
+		"INSERT INTO people (id, age) VALUES (3, 40)",
+		"INSERT INTO people (id, id) VALUES (3, 4)",
+		"INSERT INTO people VALUES (3, 'x', 4)",
+		"INSERT INTO films (release_date) VALUES ('yesterday')",
+		"INSERT INTO people SELECT title, id FROM films",
+		"INSERT INTO people VALUES (id, 'x')",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			stmt, err := parser.Parse(c)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			_, err = PlanInsert(stmt.(*sql.InsertStatement), sampleData.Database)
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPlanUpdateDeleteInvalid(t *testing.T) {
+	sampleData := storage.GetSampleData()
+
+	cases := []string{
+		"UPDATE missing SET id = 1",
+		"UPDATE people SET age = 1",
+		"UPDATE people SET id = 'one'",
+		"UPDATE people SET id = 1, id = 2",
+		"UPDATE people SET id = 1 WHERE name",
+		"UPDATE people SET id = 1 WHERE title = 'x'",
+		"UPDATE films SET release_date = 'tomorrow'",
+		"DELETE FROM missing",
+		"DELETE FROM people WHERE id",
+		"DELETE FROM people WHERE films.id = 1",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			stmt, err := parser.Parse(c)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			switch stmt := stmt.(type) {
+			case *sql.UpdateStatement:
+				_, err = PlanUpdate(stmt, sampleData.Database)
+			case *sql.DeleteStatement:
+				_, err = PlanDelete(stmt, sampleData.Database)
+			}
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPlanCreateTableInvalid(t *testing.T) {
+	cases := []string{
+		"CREATE TABLE t (x blob)",
+		"CREATE TABLE t (x integer, x text)",
+		"CREATE TABLE t (x integer DEFAULT 'one')",
+		"CREATE TABLE t (x integer DEFAULT y)",
+		"CREATE TABLE t (x date DEFAULT 'today')",
+		"CREATE TABLE t (x integer DEFAULT count(*))",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			stmt, err := parser.Parse(c)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			_, err = PlanCreateTable(stmt.(*sql.CreateTableStatement))
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPlanJoinStrategy(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	films := query.NewLoad("films", sampleData.Films.Schema)
+	people := query.NewLoad("people", sampleData.People.Schema)
+	director := query.NewColumnReference(2, types.TypeDecimal)
+	id := query.NewColumnReference(0, types.TypeDecimal)
+	sorted := func(from query.QueryPlan, key query.Expression, descending bool) query.QueryPlan {
+		s, err := query.NewSort(from, []query.SortKey{{Expression: key, Descending: descending}})
+		if err != nil {
+			t.Fatalf("unexpected error in NewSort: %v", err)
+		}
+		return s
+	}
+	condition := func(operator query.BinaryOperator) query.Expression {
+		return &query.BinaryOperation{Left: director, Right: query.NewColumnReference(4, types.TypeDecimal), Operator: operator}
+	}
+
+	cases := []struct {
+		name        string
+		left, right query.QueryPlan
+		condition   query.Expression
+		want        string
+	}{
+		{name: "non equi condition", left: films, right: people, condition: condition(query.BinaryOperatorLt), want: "*query.Join"},
+		{name: "unsorted inputs", left: films, right: people, condition: condition(query.BinaryOperatorEq), want: "*query.HashJoin"},
+		{name: "one sorted input", left: sorted(films, director, false), right: people, condition: condition(query.BinaryOperatorEq), want: "*query.HashJoin"},
+		{name: "descending inputs", left: sorted(films, director, true), right: sorted(people, id, true), condition: condition(query.BinaryOperatorEq), want: "*query.HashJoin"},
+		{name: "sorted inputs", left: sorted(films, director, false), right: sorted(people, id, false), condition: condition(query.BinaryOperatorEq), want: "*query.MergeJoin"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := planJoin(query.JoinTypeInner, c.left, c.right, c.condition, sampleData.Database)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name := fmt.Sprintf("%T", got); name != c.want {
+				t.Errorf("planJoin() = %s, want %s", name, c.want)
+			}
+		})
+	}
+}
+
+// TestPlanMergeJoinRewrite joins inputs sorted by hand, as SQL statements cannot sort the inputs of a join,
+// and checks that the merge joins are kept when filters are pushed down and unused columns are pruned
+func TestPlanMergeJoinRewrite(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	db := sampleData.Database
+	sorted := func(from query.QueryPlan, index int) query.QueryPlan {
+		s, err := query.NewSort(from, []query.SortKey{{Expression: query.NewColumnReference(index, types.TypeDecimal)}})
+		if err != nil {
+			t.Fatalf("unexpected error in NewSort: %v", err)
+		}
+		return s
+	}
+	equal := func(left, right int) query.Expression {
+		return &query.BinaryOperation{
+			Left:     query.NewColumnReference(left, types.TypeDecimal),
+			Right:    query.NewColumnReference(right, types.TypeDecimal),
+			Operator: query.BinaryOperatorEq,
+		}
+	}
+
+	// films by director joined with people by id, whose rows remain ordered by director for the second join
+	directors, err := planJoin(query.JoinTypeInner, sorted(query.NewLoad("films", sampleData.Films.Schema), 2), sorted(query.NewLoad("people", sampleData.People.Schema), 0), equal(2, 4), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	others, err := planJoin(query.JoinTypeInner, directors, sorted(query.NewAliasedLoad("films", "f", sampleData.Films.Schema), 2), equal(2, 8), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selection, err := query.NewSelect(others, &query.BinaryOperation{
+		Left:     query.NewColumnReference(5, types.TypeText),
+		Right:    query.NewConstant(types.NewText("Frank Darabont")),
+		Operator: query.BinaryOperatorEq,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	project, err := query.NewProject(selection, []query.OutputColumn{
+		query.SimpleColumn("films.title", 1, types.TypeText),
+		query.SimpleColumn("f.title", 7, types.TypeText),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := rewrite(project, db)
+	if err != nil {
+		t.Fatalf("rewrite() unexpected error: %v", err)
+	}
+	plan, err = pruneColumns(plan, db)
+	if err != nil {
+		t.Fatalf("pruneColumns() unexpected error: %v", err)
+	}
+	var nodes []string
+	query.Walk(nodeCollector(func(plan query.QueryPlan) {
+		nodes = append(nodes, query.NodeName(plan))
+	}), plan)
+	want := []string{
+		"Project", "MergeJoin",
+		"Project", "MergeJoin", "Project", "Sort", "Load", "Project", "Sort", "Select", "Load",
+		"Project", "Sort", "Load",
+	}
+	if !reflect.DeepEqual(nodes, want) {
+		t.Errorf("plan nodes = %v, want %v\n%s", nodes, want, printPlan(plan))
+	}
+
+	relation, err := plan.Run(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if len(relation.Rows) != 4 {
+		t.Errorf("Run() = %v, want the 4 pairs of films by Frank Darabont", relation.Rows)
+	}
+}
+
+type nodeCollector func(query.QueryPlan)
+
+func (c nodeCollector) Visit(plan query.QueryPlan) query.Visitor {
+	c(plan)
+	return c
+}
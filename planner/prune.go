@@ -0,0 +1,187 @@
+package planner
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"sort"
+)
+
+// pruneColumns narrows the inputs of the joins of a plan to the columns read above them, so that the combined
+// rows only hold what is needed. The columns produced by the plan itself are left unchanged.
+func pruneColumns(plan query.QueryPlan, db *storage.Database) (query.QueryPlan, error) {
+	pruned, _, err := prune(plan, allColumns(plan), db)
+	return pruned, err
+}
+
+// prune rewrites a plan of which only the required columns are read. It returns the new plan along with the
+// columns of the original plan it produces, in order, which include the required ones and possibly others.
+func prune(plan query.QueryPlan, required []int, db *storage.Database) (query.QueryPlan, []int, error) {
+	switch p := plan.(type) {
+	case *query.Project:
+		var needed []int
+		for _, i := range required {
+			needed = union(needed, referencedColumns(p.Columns[i].Expression))
+		}
+		from, columns, err := prune(p.From, needed, db)
+		if err != nil {
+			return nil, nil, err
+		}
+		kept := make([]query.OutputColumn, len(required))
+		for i, c := range required {
+			kept[i] = query.OutputColumn{Name: p.Columns[c].Name, Expression: remapColumns(p.Columns[c].Expression, columns)}
+		}
+		result, err := query.NewProject(from, kept)
+		return result, required, err
+	case *query.Select:
+		from, columns, err := prune(p.From, union(required, referencedColumns(p.Condition)), db)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := query.NewSelect(from, remapColumns(p.Condition, columns))
+		return result, columns, err
+	case *query.Sort:
+		from, columns, err := prune(p.From, union(required, keyColumns(p.Keys)), db)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := query.NewSort(from, remapKeys(p.Keys, columns))
+		return result, columns, err
+	case *query.TopN:
+		from, columns, err := prune(p.From, union(required, keyColumns(p.Keys)), db)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := query.NewTopN(from, remapKeys(p.Keys, columns), p.Offset, p.Count)
+		return result, columns, err
+	case *query.Limit:
+		from, columns, err := prune(p.From, required, db)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := query.NewLimit(from, p.Offset, p.Count)
+		return result, columns, err
+	case *query.Aggregate:
+		var needed []int
+		for _, g := range p.GroupBy {
+			needed = union(needed, referencedColumns(g.Expression))
+		}
+		for _, a := range p.Aggregates {
+			if a.Argument != nil {
+				needed = union(needed, referencedColumns(a.Argument))
+			}
+		}
+		from, columns, err := prune(p.From, needed, db)
+		if err != nil {
+			return nil, nil, err
+		}
+		groupBy := make([]query.OutputColumn, len(p.GroupBy))
+		for i, g := range p.GroupBy {
+			groupBy[i] = query.OutputColumn{Name: g.Name, Expression: remapColumns(g.Expression, columns)}
+		}
+		aggregates := make([]query.AggregateCall, len(p.Aggregates))
+		for i, a := range p.Aggregates {
+			aggregates[i] = a
+			if a.Argument != nil {
+				aggregates[i].Argument = remapColumns(a.Argument, columns)
+			}
+		}
+		result, err := query.NewAggregate(from, groupBy, aggregates)
+		return result, allColumns(plan), err
+	case *query.Join, *query.HashJoin, *query.MergeJoin:
+		return pruneJoin(p, required, db)
+	}
+	// the columns of a table and of constant rows are all produced
+	return plan, allColumns(plan), nil
+}
+
+// pruneJoin projects each input of a join on the columns required above it and by its condition
+func pruneJoin(plan query.QueryPlan, required []int, db *storage.Database) (query.QueryPlan, []int, error) {
+	t, left, right, condition, _ := joinParts(plan)
+	width := len(left.Schema().Columns)
+	needed := union(required, referencedColumns(condition))
+	split := sort.SearchInts(needed, width)
+	var rightNeeded []int
+	for _, c := range needed[split:] {
+		rightNeeded = append(rightNeeded, c-width)
+	}
+
+	left, leftColumns, err := narrow(left, needed[:split], db)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, rightColumns, err := narrow(right, rightNeeded, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := leftColumns
+	for _, c := range rightColumns {
+		columns = append(columns, c+width)
+	}
+	result, err := planJoin(t, left, right, remapColumns(condition, columns), db)
+	return result, columns, err
+}
+
+// narrow prunes an input of a join and projects it on the required columns when it still produces others
+func narrow(plan query.QueryPlan, required []int, db *storage.Database) (query.QueryPlan, []int, error) {
+	pruned, columns, err := prune(plan, required, db)
+	if err != nil || len(columns) == len(required) {
+		return pruned, columns, err
+	}
+	schema := pruned.Schema()
+	projected := make([]query.OutputColumn, len(required))
+	for i, c := range required {
+		position := sort.SearchInts(columns, c)
+		column := schema.Columns[position]
+		projected[i] = query.SimpleColumn(column.Name, position, column.Type)
+	}
+	result, err := query.NewProject(pruned, projected)
+	return result, required, err
+}
+
+// remapColumns replaces the index of every column referenced by an expression by its position in columns
+func remapColumns(e query.Expression, columns []int) query.Expression {
+	return mapColumns(e, func(c query.ColumnReference) query.Expression {
+		return query.NewColumnReference(sort.SearchInts(columns, c.Index), c.T)
+	})
+}
+
+func remapKeys(keys []query.SortKey, columns []int) []query.SortKey {
+	remapped := make([]query.SortKey, len(keys))
+	for i, key := range keys {
+		remapped[i] = key
+		remapped[i].Expression = remapColumns(key.Expression, columns)
+	}
+	return remapped
+}
+
+func keyColumns(keys []query.SortKey) []int {
+	var columns []int
+	for _, key := range keys {
+		columns = union(columns, referencedColumns(key.Expression))
+	}
+	return columns
+}
+
+func allColumns(plan query.QueryPlan) []int {
+	columns := make([]int, len(plan.Schema().Columns))
+	for i := range columns {
+		columns[i] = i
+	}
+	return columns
+}
+
+// union merges two ascending lists of column indexes
+func union(a, b []int) []int {
+	var result []int
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || len(a) > 0 && a[0] < b[0]:
+			result, a = append(result, a[0]), a[1:]
+		case len(a) == 0 || b[0] < a[0]:
+			result, b = append(result, b[0]), b[1:]
+		default:
+			result, a, b = append(result, a[0]), a[1:], b[1:]
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package planner
+
+import (
+	"context"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func TestPruneColumns(t *testing.T) {
+	db := storage.GetSampleData().Database
+	plan, err := Plan(parse(t, "SELECT people.name FROM films JOIN people JOIN films AS f ON people.id = f.director ON films.director = people.id"), db)
+	if err != nil {
+		t.Fatalf("Plan() unexpected error: %v", err)
+	}
+	// the projection above the inner join keeps the key of the outer one
+	checkPlan(t, plan, `Project {
+		From:
+		HashJoin {
+			Type: inner
+			Build: right
+			Left:
+			Project {
+				From:
+				`+filmsLoad+`
+				Columns:
+				films.director: ColumnReference(2,decimal)
+			}
+			Right:
+			Project {
+				From:
+				HashJoin {
+					Type: inner
+					Build: left
+					Left:
+					`+peopleLoad+`
+					Right:
+					Project {
+						From:
+						Load {
+							Table: "films"
+							Alias: "f"
+							Schema: TableSchema(f.id decimal, f.title text, f.director decimal, f.release_date date)
+						}
+						Columns:
+						f.director: ColumnReference(2,decimal)
+					}
+					Keys:
+					ColumnReference(0,decimal) = ColumnReference(0,decimal)
+				}
+				Columns:
+				people.id: ColumnReference(0,decimal)
+				people.name: ColumnReference(1,text)
+			}
+			Keys:
+			ColumnReference(0,decimal) = ColumnReference(0,decimal)
+		}
+		Columns:
+		people.name: ColumnReference(2,text)
+	}`)
+}
+
+// TestPruneColumnsResults checks the rows of queries reading a few of the columns of joined tables
+func TestPruneColumnsResults(t *testing.T) {
+	db := storage.GetSampleData().Database
+	cases := []struct {
+		stmt string
+		want [][]types.Value
+	}{
+		{
+			stmt: "SELECT COUNT(*) FROM films JOIN people JOIN films AS f ON people.id = f.director ON films.director = people.id",
+			want: [][]types.Value{{types.NewDecimal("5")}},
+		},
+		{
+			stmt: "SELECT name, COUNT(*) FROM films LEFT JOIN people ON films.director = people.id GROUP BY name ORDER BY name",
+			want: [][]types.Value{
+				{types.NewText("Francis Ford Coppola"), types.NewDecimal("1")},
+				{types.NewText("Frank Darabont"), types.NewDecimal("2")},
+			},
+		},
+		{
+			// neither input keeps a column, the joined rows must still be counted
+			stmt: "SELECT COUNT(*) FROM films JOIN people ON TRUE",
+			want: [][]types.Value{{types.NewDecimal("6")}},
+		},
+		{
+			stmt: "SELECT COUNT(*) FROM films LEFT JOIN people ON TRUE",
+			want: [][]types.Value{{types.NewDecimal("6")}},
+		},
+		{
+			stmt: "SELECT 1 AS one FROM films JOIN people ON TRUE",
+			want: [][]types.Value{
+				{types.NewDecimal("1")}, {types.NewDecimal("1")}, {types.NewDecimal("1")},
+				{types.NewDecimal("1")}, {types.NewDecimal("1")}, {types.NewDecimal("1")},
+			},
+		},
+		{
+			stmt: "SELECT title, name FROM films JOIN people ON films.id > people.id ORDER BY release_date, name",
+			want: [][]types.Value{
+				{types.NewText("The Godfather"), types.NewText("Frank Darabont")},
+				{types.NewText("The Dark Knight"), types.NewText("Francis Ford Coppola")},
+				{types.NewText("The Dark Knight"), types.NewText("Frank Darabont")},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.stmt, func(t *testing.T) {
+			plan, err := Plan(parse(t, c.stmt), db)
+			if err != nil {
+				t.Fatalf("Plan() unexpected error: %v", err)
+			}
+			got, err := plan.Run(context.Background(), db)
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Rows, c.want) {
+				t.Errorf("Run() = %v, want %v", got.Rows, c.want)
+			}
+		})
+	}
+}
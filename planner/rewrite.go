@@ -0,0 +1,333 @@
+package planner
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// rule rewrites a node of a plan into an equivalent one, it returns nil when it does not apply
+type rule func(plan query.QueryPlan, db *storage.Database) (query.QueryPlan, error)
+
+// rules are applied in order to every node of a plan once its inputs have been rewritten
+var rules = []rule{
+	simplifyConditions,
+	propagateEmpty,
+	mergeSelects,
+	pushSelectThroughSort,
+	pushSelectThroughProject,
+	pushSelectThroughAggregate,
+	pushPredicatesIntoJoin,
+}
+
+// rewrite applies the rules to a plan bottom up. A node is rewritten again whenever a rule applies to it,
+// so that the filters moved into its inputs keep being pushed down.
+func rewrite(plan query.QueryPlan, db *storage.Database) (query.QueryPlan, error) {
+	children := plan.Children()
+	if len(children) > 0 {
+		rewritten := make([]query.QueryPlan, len(children))
+		for i, child := range children {
+			var err error
+			rewritten[i], err = rewrite(child, db)
+			if err != nil {
+				return nil, err
+			}
+		}
+		plan = query.WithChildren(plan, rewritten)
+	}
+	for _, r := range rules {
+		next, err := r(plan, db)
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			return rewrite(next, db)
+		}
+	}
+	return plan, nil
+}
+
+// mergeSelects turns a filter over a filter into a single one
+func mergeSelects(plan query.QueryPlan, _ *storage.Database) (query.QueryPlan, error) {
+	outer, ok := plan.(*query.Select)
+	if !ok {
+		return nil, nil
+	}
+	inner, ok := outer.From.(*query.Select)
+	if !ok {
+		return nil, nil
+	}
+	return query.NewSelect(inner.From, &query.And{Left: inner.Condition, Right: outer.Condition})
+}
+
+// pushSelectThroughSort filters the rows before they are sorted, which does not change their order
+func pushSelectThroughSort(plan query.QueryPlan, _ *storage.Database) (query.QueryPlan, error) {
+	selection, ok := plan.(*query.Select)
+	if !ok {
+		return nil, nil
+	}
+	sorted, ok := selection.From.(*query.Sort)
+	if !ok {
+		return nil, nil
+	}
+	from, err := query.NewSelect(sorted.From, selection.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewSort(from, sorted.Keys)
+}
+
+// pushSelectThroughProject filters the rows before they are projected, replacing the output columns
+// referenced by the condition with the expressions computing them
+func pushSelectThroughProject(plan query.QueryPlan, _ *storage.Database) (query.QueryPlan, error) {
+	selection, ok := plan.(*query.Select)
+	if !ok {
+		return nil, nil
+	}
+	project, ok := selection.From.(*query.Project)
+	if !ok {
+		return nil, nil
+	}
+	condition := mapColumns(selection.Condition, func(c query.ColumnReference) query.Expression {
+		return project.Columns[c.Index].Expression
+	})
+	from, err := query.NewSelect(project.From, condition)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewProject(from, project.Columns)
+}
+
+// pushSelectThroughAggregate filters the rows before they are grouped when the condition only
+// references the group columns, as in HAVING year > 2000 with GROUP BY year
+func pushSelectThroughAggregate(plan query.QueryPlan, _ *storage.Database) (query.QueryPlan, error) {
+	selection, ok := plan.(*query.Select)
+	if !ok {
+		return nil, nil
+	}
+	aggregate, ok := selection.From.(*query.Aggregate)
+	// without any group column the input always forms a group, even when none of its rows is kept
+	if !ok || len(aggregate.GroupBy) == 0 {
+		return nil, nil
+	}
+	groups := len(aggregate.GroupBy)
+	var pushed, kept []query.Expression
+	for _, e := range conjuncts(selection.Condition) {
+		if columns := referencedColumns(e); len(columns) > 0 && columns[len(columns)-1] < groups {
+			pushed = append(pushed, e)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if len(pushed) == 0 {
+		return nil, nil
+	}
+	condition := mapColumns(conjunction(pushed), func(c query.ColumnReference) query.Expression {
+		return aggregate.GroupBy[c.Index].Expression
+	})
+	from, err := query.NewSelect(aggregate.From, condition)
+	if err != nil {
+		return nil, err
+	}
+	result, err := query.NewAggregate(from, aggregate.GroupBy, aggregate.Aggregates)
+	if err != nil {
+		return nil, err
+	}
+	return filter(result, kept)
+}
+
+// pushPredicatesIntoJoin moves the filters above a join and the parts of its condition that only reference
+// one of its inputs into that input, unless the input is preserved by an outer join: a filter above the join
+// would then drop the rows padded with NULLs, and a condition of the join must not drop the preserved rows.
+// Filters of an inner join referencing both inputs become part of its condition, which may turn a nested loop
+// join into a hash join. The join strategy is chosen again for the new inputs.
+func pushPredicatesIntoJoin(plan query.QueryPlan, db *storage.Database) (query.QueryPlan, error) {
+	var where []query.Expression
+	if selection, ok := plan.(*query.Select); ok {
+		where = conjuncts(selection.Condition)
+		plan = selection.From
+	}
+	t, left, right, condition, ok := joinParts(plan)
+	if !ok {
+		return nil, nil
+	}
+	width := len(left.Schema().Columns)
+	inner := t == query.JoinTypeInner
+
+	var leftPredicates, rightPredicates, on, kept []query.Expression
+	for _, e := range conjuncts(condition) {
+		switch side(e, width) {
+		case sideLeft:
+			if inner || t == query.JoinTypeRightOuter {
+				leftPredicates = append(leftPredicates, e)
+				continue
+			}
+		case sideRight:
+			if inner || t == query.JoinTypeLeftOuter {
+				rightPredicates = append(rightPredicates, shiftColumns(e, -width))
+				continue
+			}
+		}
+		on = append(on, e)
+	}
+	merged := false
+	for _, e := range where {
+		switch side(e, width) {
+		case sideLeft:
+			if inner || t == query.JoinTypeLeftOuter {
+				leftPredicates = append(leftPredicates, e)
+				continue
+			}
+		case sideRight:
+			if inner || t == query.JoinTypeRightOuter {
+				rightPredicates = append(rightPredicates, shiftColumns(e, -width))
+				continue
+			}
+		}
+		if inner {
+			on = append(on, e)
+			merged = true
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if len(leftPredicates) == 0 && len(rightPredicates) == 0 && !merged {
+		return nil, nil
+	}
+
+	left, err := filter(left, leftPredicates)
+	if err != nil {
+		return nil, err
+	}
+	right, err = filter(right, rightPredicates)
+	if err != nil {
+		return nil, err
+	}
+	var joinCondition query.Expression = query.NewConstant(types.NewBoolean(true))
+	if len(on) > 0 {
+		joinCondition = conjunction(on)
+	}
+	join, err := planJoin(t, left, right, joinCondition, db)
+	if err != nil {
+		return nil, err
+	}
+	return filter(join, kept)
+}
+
+const (
+	sideNone = iota
+	sideLeft
+	sideRight
+	sideBoth
+)
+
+// side tells which inputs of a join a predicate references, the columns of the right input start at width
+func side(e query.Expression, width int) int {
+	columns := referencedColumns(e)
+	switch {
+	case len(columns) == 0:
+		return sideNone
+	case columns[len(columns)-1] < width:
+		return sideLeft
+	case columns[0] >= width:
+		return sideRight
+	}
+	return sideBoth
+}
+
+// joinParts returns the type, the inputs and the whole condition of a join, in which the keys of
+// a hash or merge join are equalities between columns of the combined schema
+func joinParts(plan query.QueryPlan) (t query.JoinType, left, right query.QueryPlan, condition query.Expression, ok bool) {
+	var leftKeys, rightKeys []query.Expression
+	var residual query.Expression
+	switch j := plan.(type) {
+	case *query.Join:
+		return j.Type, j.Left, j.Right, j.Condition, true
+	case *query.HashJoin:
+		t, left, right, leftKeys, rightKeys, residual = j.Type, j.Left, j.Right, j.LeftKeys, j.RightKeys, j.Condition
+	case *query.MergeJoin:
+		t, left, right, leftKeys, rightKeys, residual = j.Type, j.Left, j.Right, j.LeftKeys, j.RightKeys, j.Condition
+	default:
+		return 0, nil, nil, nil, false
+	}
+	width := len(left.Schema().Columns)
+	var parts []query.Expression
+	for i := range leftKeys {
+		parts = append(parts, &query.BinaryOperation{
+			Left:     leftKeys[i],
+			Right:    shiftColumns(rightKeys[i], width),
+			Operator: query.BinaryOperatorEq,
+		})
+	}
+	if residual != nil {
+		parts = append(parts, residual)
+	}
+	return t, left, right, conjunction(parts), true
+}
+
+// filter keeps the rows of a plan for which every condition holds
+func filter(plan query.QueryPlan, conditions []query.Expression) (query.QueryPlan, error) {
+	if len(conditions) == 0 {
+		return plan, nil
+	}
+	return query.NewSelect(plan, conjunction(conditions))
+}
+
+// referencedColumns returns the indexes of the columns an expression references, in ascending order
+func referencedColumns(e query.Expression) []int {
+	collector := &columnCollector{seen: make(map[int]bool)}
+	query.WalkExpression(collector, e)
+	var columns []int
+	for i := 0; len(columns) < len(collector.seen); i++ {
+		if collector.seen[i] {
+			columns = append(columns, i)
+		}
+	}
+	return columns
+}
+
+type columnCollector struct {
+	seen map[int]bool
+}
+
+func (c *columnCollector) VisitExpression(e query.Expression) query.ExpressionVisitor {
+	if column, ok := e.(query.ColumnReference); ok {
+		c.seen[column.Index] = true
+	}
+	return c
+}
+
+// shiftColumns adds offset to the index of every column referenced by an expression
+func shiftColumns(e query.Expression, offset int) query.Expression {
+	return mapColumns(e, func(c query.ColumnReference) query.Expression {
+		return query.NewColumnReference(c.Index+offset, c.T)
+	})
+}
+
+// mapColumns returns a copy of an expression in which every column reference is replaced by f
+func mapColumns(e query.Expression, f func(query.ColumnReference) query.Expression) query.Expression {
+	switch e := e.(type) {
+	case query.ColumnReference:
+		return f(e)
+	case *query.BinaryOperation:
+		c := *e
+		c.Left, c.Right = mapColumns(e.Left, f), mapColumns(e.Right, f)
+		return &c
+	case *query.ArithmeticOperation:
+		c := *e
+		c.Left, c.Right = mapColumns(e.Left, f), mapColumns(e.Right, f)
+		return &c
+	case *query.And:
+		return &query.And{Left: mapColumns(e.Left, f), Right: mapColumns(e.Right, f)}
+	case *query.Or:
+		return &query.Or{Left: mapColumns(e.Left, f), Right: mapColumns(e.Right, f)}
+	case *query.Not:
+		return &query.Not{Expression: mapColumns(e.Expression, f)}
+	case *query.Negation:
+		return &query.Negation{Expression: mapColumns(e.Expression, f)}
+	case *query.IsNull:
+		return &query.IsNull{Expression: mapColumns(e.Expression, f), Negated: e.Negated}
+	}
+	// constants do not reference any column
+	return e
+}
@@ -0,0 +1,216 @@
+package planner
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"strings"
+	"testing"
+)
+
+func printPlan(plan query.QueryPlan) string {
+	printer := query.NewPrinter()
+	plan.Print(printer)
+	return printer.String()
+}
+
+// checkPlan compares the printed plans, ignoring the indentation of want
+func checkPlan(t *testing.T, got query.QueryPlan, want string) {
+	t.Helper()
+	var lines []string
+	for _, line := range strings.Split(printPlan(got), "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	var wantLines []string
+	for _, line := range strings.Split(strings.TrimSpace(want), "\n") {
+		wantLines = append(wantLines, strings.TrimSpace(line))
+	}
+	if g, w := strings.TrimSpace(strings.Join(lines, "\n")), strings.Join(wantLines, "\n"); g != w {
+		t.Errorf("got plan\n%s\nwant\n%s", printPlan(got), want)
+	}
+}
+
+const (
+	filmsLoad = `Load {
+		Table: "films"
+		Schema: TableSchema(films.id decimal, films.title text, films.director decimal, films.release_date date)
+	}`
+	peopleLoad = `Load {
+		Table: "people"
+		Schema: TableSchema(people.id decimal, people.name text)
+	}`
+)
+
+func TestPredicatePushdown(t *testing.T) {
+	db := storage.GetSampleData().Database
+	cases := []struct {
+		name string
+		stmt string
+		want string
+	}{
+		{
+			name: "filters go below an inner join",
+			stmt: "SELECT title FROM films JOIN people ON films.director = people.id WHERE films.release_date > '2000-01-01' AND people.name = 'Frank Darabont'",
+			want: `Project {
+				From:
+				HashJoin {
+					Type: inner
+					Build: right
+					Left:
+					Project {
+						From:
+						Select {
+							From:
+							` + filmsLoad + `
+							Condition: BinaryOperation(ColumnReference(3,date) gt Date(2000- 1- 1))
+						}
+						Columns:
+						films.title: ColumnReference(1,text)
+						films.director: ColumnReference(2,decimal)
+					}
+					Right:
+					Project {
+						From:
+						Select {
+							From:
+							` + peopleLoad + `
+							Condition: BinaryOperation(ColumnReference(1,text) eq "Frank Darabont")
+						}
+						Columns:
+						people.id: ColumnReference(0,decimal)
+					}
+					Keys:
+					ColumnReference(1,decimal) = ColumnReference(0,decimal)
+				}
+				Columns:
+				films.title: ColumnReference(0,text)
+			}`,
+		},
+		{
+			name: "a filter on the side padded with NULLs stays above an outer join",
+			stmt: "SELECT * FROM films LEFT JOIN people ON films.director = people.id WHERE people.name IS NULL AND films.id > 1",
+			want: `Select {
+				From:
+				HashJoin {
+					Type: left outer
+					Build: right
+					Left:
+					Select {
+						From:
+						` + filmsLoad + `
+						Condition: BinaryOperation(ColumnReference(0,decimal) gt 1)
+					}
+					Right:
+					` + peopleLoad + `
+					Keys:
+					ColumnReference(2,decimal) = ColumnReference(0,decimal)
+				}
+				Condition: IsNull(ColumnReference(5,text))
+			}`,
+		},
+		{
+			name: "a join condition on the preserved side stays in an outer join",
+			stmt: "SELECT * FROM films LEFT JOIN people ON films.director = people.id AND films.id > 1 AND people.id > 1",
+			want: `HashJoin {
+				Type: left outer
+				Build: right
+				Left:
+				` + filmsLoad + `
+				Right:
+				Select {
+					From:
+					` + peopleLoad + `
+					Condition: BinaryOperation(ColumnReference(0,decimal) gt 1)
+				}
+				Keys:
+				ColumnReference(2,decimal) = ColumnReference(0,decimal)
+				Condition: BinaryOperation(ColumnReference(0,decimal) gt 1)
+			}`,
+		},
+		{
+			name: "a filter on both sides becomes the condition of a hash join",
+			stmt: "SELECT * FROM films JOIN people ON films.id > people.id WHERE films.director = people.id",
+			want: `HashJoin {
+				Type: inner
+				Build: right
+				Left:
+				` + filmsLoad + `
+				Right:
+				` + peopleLoad + `
+				Keys:
+				ColumnReference(2,decimal) = ColumnReference(0,decimal)
+				Condition: BinaryOperation(ColumnReference(0,decimal) gt ColumnReference(4,decimal))
+			}`,
+		},
+		{
+			name: "a filter on the group columns goes below the aggregate",
+			stmt: "SELECT director, COUNT(*) FROM films GROUP BY director HAVING director > 1 AND COUNT(*) > 1",
+			want: `Project {
+				From:
+				Select {
+					From:
+					Aggregate {
+						From:
+						Select {
+							From:
+							` + filmsLoad + `
+							Condition: BinaryOperation(ColumnReference(2,decimal) gt 1)
+						}
+						GroupBy:
+						films.director: ColumnReference(2,decimal)
+						Aggregates:
+						count(*): count(*)
+					}
+					Condition: BinaryOperation(ColumnReference(1,decimal) gt 1)
+				}
+				Columns:
+				films.director: ColumnReference(0,decimal)
+				count(*): ColumnReference(1,decimal)
+			}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plan, err := Plan(parse(t, c.stmt), db)
+			if err != nil {
+				t.Fatalf("Plan() unexpected error: %v", err)
+			}
+			checkPlan(t, plan, c.want)
+		})
+	}
+}
+
+func TestPushSelectThroughProject(t *testing.T) {
+	sampleData := storage.GetSampleData()
+	project, err := query.NewProject(query.NewLoad("films", sampleData.Films.Schema), []query.OutputColumn{
+		{Name: "title", Expression: query.NewColumnReference(1, types.TypeText)},
+		{Name: "year", Expression: query.NewColumnReference(3, types.TypeDate)},
+	})
+	if err != nil {
+		t.Fatalf("NewProject() unexpected error: %v", err)
+	}
+	selection, err := query.NewSelect(project, &query.BinaryOperation{
+		Left:     query.NewColumnReference(1, types.TypeDate),
+		Right:    query.NewConstant(types.NewDate(2000, 1, 1)),
+		Operator: query.BinaryOperatorGt,
+	})
+	if err != nil {
+		t.Fatalf("NewSelect() unexpected error: %v", err)
+	}
+
+	plan, err := rewrite(selection, sampleData.Database)
+	if err != nil {
+		t.Fatalf("rewrite() unexpected error: %v", err)
+	}
+	checkPlan(t, plan, `Project {
+		From:
+		Select {
+			From:
+			`+filmsLoad+`
+			Condition: BinaryOperation(ColumnReference(3,date) gt Date(2000- 1- 1))
+		}
+		Columns:
+		title: ColumnReference(1,text)
+		year: ColumnReference(3,date)
+	}`)
+}
@@ -0,0 +1,175 @@
+package planner
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+)
+
+// simplify evaluates the parts of an expression that do not reference any column and removes the operands
+// of AND and OR that cannot change its value. Parts failing to evaluate, such as a division by zero, are
+// kept so that the error is only returned if a row is ever read.
+func simplify(e query.Expression) query.Expression {
+	switch x := e.(type) {
+	case *query.And:
+		left, right := simplify(x.Left), simplify(x.Right)
+		switch {
+		case isConstant(left, false) || isConstant(right, false):
+			return query.NewConstant(types.NewBoolean(false))
+		case isConstant(left, true):
+			return right
+		case isConstant(right, true):
+			return left
+		}
+		e = &query.And{Left: left, Right: right}
+	case *query.Or:
+		left, right := simplify(x.Left), simplify(x.Right)
+		switch {
+		case isConstant(left, true) || isConstant(right, true):
+			return query.NewConstant(types.NewBoolean(true))
+		case isConstant(left, false):
+			return right
+		case isConstant(right, false):
+			return left
+		}
+		e = &query.Or{Left: left, Right: right}
+	case *query.Not:
+		// NOT is its own inverse even for NULL, which also negates to NULL, as does a comparison with NULL
+		switch operand := simplify(x.Expression).(type) {
+		case *query.Not:
+			return operand.Expression
+		case *query.IsNull:
+			return &query.IsNull{Expression: operand.Expression, Negated: !operand.Negated}
+		case *query.BinaryOperation:
+			c := *operand
+			c.Operator = negatedOperator(operand.Operator)
+			return fold(&c)
+		default:
+			e = &query.Not{Expression: operand}
+		}
+	case *query.BinaryOperation:
+		c := *x
+		c.Left, c.Right = simplify(x.Left), simplify(x.Right)
+		e = &c
+	case *query.ArithmeticOperation:
+		c := *x
+		c.Left, c.Right = simplify(x.Left), simplify(x.Right)
+		e = &c
+	case *query.Negation:
+		e = &query.Negation{Expression: simplify(x.Expression)}
+	case *query.IsNull:
+		e = &query.IsNull{Expression: simplify(x.Expression), Negated: x.Negated}
+	}
+	return fold(e)
+}
+
+// fold replaces an expression that does not reference any column by its value
+func fold(e query.Expression) query.Expression {
+	if _, ok := e.(query.Constant); ok || len(referencedColumns(e)) > 0 {
+		return e
+	}
+	value, err := e.Evaluate(&types.Row{})
+	if err != nil {
+		return e
+	}
+	return query.NewConstant(value)
+}
+
+func negatedOperator(operator query.BinaryOperator) query.BinaryOperator {
+	switch operator {
+	case query.BinaryOperatorEq:
+		return query.BinaryOperatorNe
+	case query.BinaryOperatorNe:
+		return query.BinaryOperatorEq
+	case query.BinaryOperatorLt:
+		return query.BinaryOperatorGe
+	case query.BinaryOperatorLe:
+		return query.BinaryOperatorGt
+	case query.BinaryOperatorGt:
+		return query.BinaryOperatorLe
+	}
+	return query.BinaryOperatorLt
+}
+
+// isConstant reports whether an expression is the boolean constant value
+func isConstant(e query.Expression, value bool) bool {
+	c, ok := e.(query.Constant)
+	if !ok {
+		return false
+	}
+	b, ok := c.Value.(types.Boolean)
+	return ok && b.Bool() == value
+}
+
+// neverTrue reports whether a condition is a constant that keeps no row, either false or NULL
+func neverTrue(e query.Expression) bool {
+	c, ok := e.(query.Constant)
+	return ok && (types.IsNull(c.Value) || isConstant(c, false))
+}
+
+// simplifyConditions simplifies the conditions of filters and joins and the columns of projections. A filter that
+// always holds is removed, one that never does, as well as the condition of an inner join, produces no rows.
+func simplifyConditions(plan query.QueryPlan, db *storage.Database) (query.QueryPlan, error) {
+	switch p := plan.(type) {
+	case *query.Select:
+		condition := simplify(p.Condition)
+		switch {
+		case isConstant(condition, true):
+			return p.From, nil
+		case neverTrue(condition):
+			return query.NewEmpty(p), nil
+		case !reflect.DeepEqual(condition, p.Condition):
+			return query.NewSelect(p.From, condition)
+		}
+	case *query.Project:
+		columns := make([]query.OutputColumn, len(p.Columns))
+		for i, c := range p.Columns {
+			columns[i] = query.OutputColumn{Name: c.Name, Expression: simplify(c.Expression)}
+		}
+		if !reflect.DeepEqual(columns, p.Columns) {
+			return query.NewProject(p.From, columns)
+		}
+	case *query.Join, *query.HashJoin, *query.MergeJoin:
+		t, left, right, condition, _ := joinParts(p)
+		simplified := simplify(condition)
+		switch {
+		case t == query.JoinTypeInner && neverTrue(simplified):
+			return query.NewEmpty(p), nil
+		case !reflect.DeepEqual(simplified, condition):
+			return planJoin(t, left, right, simplified, db)
+		}
+	}
+	return nil, nil
+}
+
+// propagateEmpty turns an operator reading an input without rows into one without rows itself, when it
+// cannot produce any row of its own: an aggregate without GROUP BY returns a row even for an empty input,
+// and an outer join keeps the rows of its other input
+func propagateEmpty(plan query.QueryPlan, _ *storage.Database) (query.QueryPlan, error) {
+	empty := func(p query.QueryPlan) bool {
+		_, ok := p.(*query.Empty)
+		return ok
+	}
+	switch p := plan.(type) {
+	case *query.Select, *query.Project, *query.Sort, *query.Limit, *query.TopN:
+		if empty(p.Children()[0]) {
+			return query.NewEmpty(p), nil
+		}
+	case *query.Aggregate:
+		if len(p.GroupBy) > 0 && empty(p.From) {
+			return query.NewEmpty(p), nil
+		}
+	case *query.Join, *query.HashJoin, *query.MergeJoin:
+		t, left, right, _, _ := joinParts(p)
+		leftEmpty, rightEmpty := empty(left), empty(right)
+		switch {
+		case t == query.JoinTypeInner && (leftEmpty || rightEmpty),
+			t == query.JoinTypeLeftOuter && leftEmpty,
+			t == query.JoinTypeRightOuter && rightEmpty,
+			leftEmpty && rightEmpty:
+			return query.NewEmpty(p), nil
+		}
+	}
+	return nil, nil
+}
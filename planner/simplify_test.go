@@ -0,0 +1,122 @@
+package planner
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/sql/query"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	price := query.NewColumnReference(0, types.TypeDecimal)
+	positive := &query.BinaryOperation{Left: price, Right: query.NewConstant(types.NewDecimal("0")), Operator: query.BinaryOperatorGt}
+	constant := func(n string) query.Expression {
+		return query.NewConstant(types.NewDecimal(n))
+	}
+	cases := []struct {
+		name string
+		expr query.Expression
+		want query.Expression
+	}{
+		{
+			name: "constant comparison",
+			expr: &query.BinaryOperation{Left: constant("1"), Right: constant("1"), Operator: query.BinaryOperatorEq},
+			want: query.NewConstant(types.NewBoolean(true)),
+		},
+		{
+			name: "constant operand of a comparison",
+			expr: &query.BinaryOperation{
+				Left:     &query.ArithmeticOperation{Left: constant("2"), Right: constant("3"), Operator: query.ArithmeticOperatorAdd},
+				Right:    price,
+				Operator: query.BinaryOperatorGt,
+			},
+			want: &query.BinaryOperation{Left: constant("5"), Right: price, Operator: query.BinaryOperatorGt},
+		},
+		{
+			name: "tautology in a conjunction",
+			expr: &query.And{Left: positive, Right: query.NewConstant(types.NewBoolean(true))},
+			want: positive,
+		},
+		{
+			name: "contradiction in a conjunction",
+			expr: &query.And{Left: positive, Right: &query.BinaryOperation{Left: constant("1"), Right: constant("2"), Operator: query.BinaryOperatorGt}},
+			want: query.NewConstant(types.NewBoolean(false)),
+		},
+		{
+			name: "tautology in a disjunction",
+			expr: &query.Or{Left: query.NewConstant(types.NewBoolean(true)), Right: positive},
+			want: query.NewConstant(types.NewBoolean(true)),
+		},
+		{
+			name: "double negation",
+			expr: &query.Not{Expression: &query.Not{Expression: positive}},
+			want: positive,
+		},
+		{
+			name: "negated comparison",
+			expr: &query.Not{Expression: positive},
+			want: &query.BinaryOperation{Left: price, Right: constant("0"), Operator: query.BinaryOperatorLe},
+		},
+		{
+			name: "negated IS NULL",
+			expr: &query.Not{Expression: &query.IsNull{Expression: price}},
+			want: &query.IsNull{Expression: price, Negated: true},
+		},
+		{
+			name: "comparison with NULL",
+			expr: &query.BinaryOperation{Left: query.NewConstant(types.Null{T: types.TypeDecimal}), Right: constant("1"), Operator: query.BinaryOperatorEq},
+			want: query.NewConstant(types.Null{T: types.TypeBoolean}),
+		},
+		{
+			name: "failing expression is kept",
+			expr: &query.ArithmeticOperation{Left: constant("1"), Right: constant("0"), Operator: query.ArithmeticOperatorDiv},
+			want: &query.ArithmeticOperation{Left: constant("1"), Right: constant("0"), Operator: query.ArithmeticOperatorDiv},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := simplify(c.expr); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("simplify(%s) = %s, want %s", c.expr, got, c.want)
+			}
+		})
+	}
+}
+
+func TestPlanContradiction(t *testing.T) {
+	db := storage.GetSampleData().Database
+	cases := []struct {
+		name string
+		stmt string
+		want string
+	}{
+		{
+			name: "always false filter",
+			stmt: "SELECT title FROM films WHERE 1 = 0",
+			want: `Empty {
+				Schema: TableSchema(films.title text)
+			}`,
+		},
+		{
+			name: "contradiction on one side of an inner join",
+			stmt: "SELECT * FROM films JOIN people ON films.director = people.id WHERE people.name = 'Frank Darabont' AND 1 > 2",
+			want: `Empty {
+				Schema: TableSchema(films.id decimal, films.title text, films.director decimal, films.release_date date, people.id decimal, people.name text)
+			}`,
+		},
+		{
+			name: "always true filter",
+			stmt: "SELECT * FROM films WHERE 1 = 1 OR id > 2",
+			want: filmsLoad,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plan, err := Plan(parse(t, c.stmt), db)
+			if err != nil {
+				t.Fatalf("Plan() unexpected error: %v", err)
+			}
+			checkPlan(t, plan, c.want)
+		})
+	}
+}
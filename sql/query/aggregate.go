@@ -0,0 +1,334 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"strconv"
+	"strings"
+)
+
+type AggregateFunction int
+
+const (
+	AggregateCount AggregateFunction = iota
+	AggregateSum
+	AggregateAvg
+	AggregateMin
+	AggregateMax
+)
+
+func (f AggregateFunction) String() string {
+	switch f {
+	case AggregateCount:
+		return "count"
+	case AggregateSum:
+		return "sum"
+	case AggregateAvg:
+		return "avg"
+	case AggregateMin:
+		return "min"
+	case AggregateMax:
+		return "max"
+	}
+	return "unknown"
+}
+
+// AggregateCall is one aggregate computed per group. Argument is nil for COUNT(*),
+// otherwise NULL arguments are ignored, and Distinct ignores repeated arguments.
+type AggregateCall struct {
+	Name     string
+	Function AggregateFunction
+	Argument Expression
+	Distinct bool
+}
+
+func (c AggregateCall) Type() types.Type {
+	switch c.Function {
+	case AggregateMin, AggregateMax:
+		return c.Argument.Type()
+	}
+	return types.TypeDecimal
+}
+
+func (c AggregateCall) Schema() types.ColumnSchema {
+	return types.ColumnSchema{
+		Name: c.Name,
+		Type: c.Type(),
+		// every aggregate but COUNT is NULL over a group without any non-NULL argument
+		Nullable: c.Function != AggregateCount,
+	}
+}
+
+func (c AggregateCall) String() string {
+	if c.Argument == nil {
+		return fmt.Sprintf("%s(*)", c.Function)
+	}
+	if c.Distinct {
+		return fmt.Sprintf("%s(distinct %s)", c.Function, c.Argument)
+	}
+	return fmt.Sprintf("%s(%s)", c.Function, c.Argument)
+}
+
+func (c AggregateCall) check(schema types.TableSchema) error {
+	if c.Argument == nil {
+		if c.Function != AggregateCount {
+			return fmt.Errorf("%s requires an argument", c.Function)
+		}
+		return nil
+	}
+	if err := c.Argument.Check(schema); err != nil {
+		return err
+	}
+	switch c.Function {
+	case AggregateSum, AggregateAvg:
+		if c.Argument.Type() != types.TypeDecimal {
+			return fmt.Errorf("%s requires a decimal argument, got %v: %s", c.Function, c.Argument.Type(), c.Argument)
+		}
+	case AggregateMin, AggregateMax:
+		if !c.Argument.Type().Comparable() {
+			return fmt.Errorf("%s requires a comparable argument, got %v: %s", c.Function, c.Argument.Type(), c.Argument)
+		}
+	}
+	return nil
+}
+
+// Aggregate groups rows by the GroupBy expressions and computes the Aggregates for each group.
+// Its output has one column per group expression followed by one column per aggregate. Without
+// any group expression the whole input is a single group, even when it is empty.
+type Aggregate struct {
+	From       QueryPlan
+	GroupBy    []OutputColumn
+	Aggregates []AggregateCall
+}
+
+func NewAggregate(from QueryPlan, groupBy []OutputColumn, aggregates []AggregateCall) (*Aggregate, error) {
+	schema := from.Schema()
+	for _, g := range groupBy {
+		if err := g.Expression.Check(schema); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range aggregates {
+		if err := a.check(schema); err != nil {
+			return nil, err
+		}
+	}
+	return &Aggregate{From: from, GroupBy: groupBy, Aggregates: aggregates}, nil
+}
+
+func (a *Aggregate) Schema() types.TableSchema {
+	from := a.From.Schema()
+	var columns []types.ColumnSchema
+	for _, g := range a.GroupBy {
+		column := g.Schema()
+		column.Nullable = nullable(g.Expression, from)
+		columns = append(columns, column)
+	}
+	for _, c := range a.Aggregates {
+		columns = append(columns, c.Schema())
+	}
+	return types.TableSchema{Columns: columns}
+}
+
+// Open reads the whole input before returning the first group
+func (a *Aggregate) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	schema := a.From.Schema()
+	from, err := a.From.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer from.Close()
+
+	// groups are kept in the order they are first seen
+	var groups []*group
+	index := make(map[string]*group)
+	if len(a.GroupBy) == 0 {
+		g := a.newGroup(nil)
+		groups = append(groups, g)
+		index[""] = g
+	}
+
+	for {
+		values, err := from.Next()
+		if err != nil {
+			return nil, err
+		}
+		if values == nil {
+			break
+		}
+		row := &types.Row{Schema: schema, Values: values}
+		keys := make([]types.Value, len(a.GroupBy))
+		for j, g := range a.GroupBy {
+			keys[j], err = g.Expression.Evaluate(row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		key := valuesKey(keys)
+		g, ok := index[key]
+		if !ok {
+			g = a.newGroup(keys)
+			groups = append(groups, g)
+			index[key] = g
+		}
+		for _, acc := range g.accumulators {
+			if err := acc.add(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rows := make([][]types.Value, len(groups))
+	for i, g := range groups {
+		values := append([]types.Value{}, g.keys...)
+		for _, acc := range g.accumulators {
+			result, err := acc.result()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, result)
+		}
+		rows[i] = values
+	}
+	return &sliceIterator{ctx: ctx, rows: rows, buffered: true}, nil
+}
+
+func (a *Aggregate) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, a, db)
+}
+
+func (a *Aggregate) Print(printer *Printer) {
+	printer.Println("Aggregate {")
+	printer.Indent()
+	printer.Println("From:")
+	a.From.Print(printer)
+	printer.Println("GroupBy:")
+	printer.Indent()
+	for _, g := range a.GroupBy {
+		printer.Println("%s: %s", g.Name, g.Expression)
+	}
+	printer.Dedent()
+	printer.Println("Aggregates:")
+	printer.Indent()
+	for _, c := range a.Aggregates {
+		printer.Println("%s: %s", c.Name, c)
+	}
+	printer.Dedent()
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (a *Aggregate) Children() []QueryPlan {
+	return []QueryPlan{a.From}
+}
+
+func (a *Aggregate) Attributes() []Attribute {
+	aggregates := make([][]Attribute, len(a.Aggregates))
+	for i, c := range a.Aggregates {
+		aggregates[i] = []Attribute{{Name: "Name", Value: c.Name}, {Name: "Function", Value: c.Function.String()}}
+		// the argument of COUNT(*) is nil
+		if c.Argument != nil {
+			aggregates[i] = append(aggregates[i], Attribute{Name: "Argument", Value: c.Argument})
+		}
+		aggregates[i] = append(aggregates[i], Attribute{Name: "Distinct", Value: c.Distinct})
+	}
+	return []Attribute{
+		{Name: "GroupBy", Value: outputColumnAttributes(a.GroupBy)},
+		{Name: "Aggregates", Value: aggregates},
+	}
+}
+
+type group struct {
+	keys         []types.Value
+	accumulators []*accumulator
+}
+
+func (a *Aggregate) newGroup(keys []types.Value) *group {
+	g := &group{keys: keys}
+	for _, call := range a.Aggregates {
+		acc := &accumulator{call: call, sum: types.DecimalZero()}
+		if call.Distinct {
+			acc.seen = make(map[string]bool)
+		}
+		g.accumulators = append(g.accumulators, acc)
+	}
+	return g
+}
+
+type accumulator struct {
+	call  AggregateCall
+	count int
+	sum   types.Decimal
+	// extreme is the current minimum or maximum
+	extreme types.Value
+	seen    map[string]bool
+}
+
+func (acc *accumulator) add(row *types.Row) error {
+	if acc.call.Argument == nil {
+		acc.count++
+		return nil
+	}
+	value, err := acc.call.Argument.Evaluate(row)
+	if err != nil || types.IsNull(value) {
+		return err
+	}
+	if acc.seen != nil {
+		key := valuesKey([]types.Value{value})
+		if acc.seen[key] {
+			return nil
+		}
+		acc.seen[key] = true
+	}
+	acc.count++
+
+	switch acc.call.Function {
+	case AggregateSum, AggregateAvg:
+		d, ok := value.(types.Decimal)
+		if !ok {
+			return fmt.Errorf("%s: expected a decimal value, got %v", acc.call, value)
+		}
+		acc.sum = acc.sum.Add(d)
+	case AggregateMin:
+		if acc.extreme == nil || value.Compare(acc.extreme) == types.ComparisonLess {
+			acc.extreme = value
+		}
+	case AggregateMax:
+		if acc.extreme == nil || value.Compare(acc.extreme) == types.ComparisonGreater {
+			acc.extreme = value
+		}
+	}
+	return nil
+}
+
+func (acc *accumulator) result() (types.Value, error) {
+	if acc.call.Function == AggregateCount {
+		return types.NewDecimal(strconv.Itoa(acc.count)), nil
+	}
+	if acc.count == 0 {
+		return types.NewNull(acc.call.Type()), nil
+	}
+	switch acc.call.Function {
+	case AggregateSum:
+		return acc.sum, nil
+	case AggregateAvg:
+		avg, err := acc.sum.Div(types.NewDecimal(strconv.Itoa(acc.count)), types.DefaultDivisionDigits, types.RoundHalfEven)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", acc.call, err)
+		}
+		return avg, nil
+	}
+	return acc.extreme, nil
+}
+
+// valuesKey builds a map key that is equal for two lists of values exactly when they are equal,
+// decimals are normalised so 1.0 and 1 share a key, and NULLs are grouped together as in SQL
+func valuesKey(values []types.Value) string {
+	builder := new(strings.Builder)
+	for _, v := range values {
+		fmt.Fprintf(builder, "%d:%s;", v.Type(), v)
+	}
+	return builder.String()
+}
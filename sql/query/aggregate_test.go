@@ -0,0 +1,111 @@
+package query
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	db, schema := sortDatabase(t)
+	a := NewColumnReference(0, types.TypeDecimal)
+	b := NewColumnReference(1, types.TypeText)
+	decimal := types.NewDecimal
+	null := types.NewNull(types.TypeDecimal)
+
+	cases := []struct {
+		name       string
+		groupBy    []OutputColumn
+		aggregates []AggregateCall
+		want       [][]types.Value
+	}{
+		{
+			name:    "group by with nulls grouped together",
+			groupBy: []OutputColumn{SimpleColumn("a", 0, types.TypeDecimal)},
+			aggregates: []AggregateCall{
+				{Name: "count(*)", Function: AggregateCount},
+				{Name: "sum(a)", Function: AggregateSum, Argument: a},
+				{Name: "max(b)", Function: AggregateMax, Argument: b},
+			},
+			want: [][]types.Value{
+				{decimal("2"), decimal("2"), decimal("4"), types.NewText("x")},
+				{null, decimal("1"), null, types.NewText("y")},
+				{decimal("1"), decimal("1"), decimal("1"), types.NewText("z")},
+			},
+		},
+		{
+			name: "single group ignoring nulls",
+			aggregates: []AggregateCall{
+				{Name: "count(a)", Function: AggregateCount, Argument: a},
+				{Name: "count(distinct a)", Function: AggregateCount, Argument: a, Distinct: true},
+				{Name: "avg(a)", Function: AggregateAvg, Argument: a},
+				{Name: "min(b)", Function: AggregateMin, Argument: b},
+			},
+			want: [][]types.Value{
+				{decimal("3"), decimal("2"), decimal("1.6666666666666667"), types.NewText("w")},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			aggregate, err := NewAggregate(NewLoad("t", schema), c.groupBy, c.aggregates)
+			if err != nil {
+				t.Fatalf("NewAggregate() = %v, want nil", err)
+			}
+			got := run(t, aggregate, db).Rows
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("aggregated rows = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAggregateEmptyInput(t *testing.T) {
+	db, schema := sortDatabase(t)
+	a := NewColumnReference(0, types.TypeDecimal)
+	empty, err := NewSelect(NewLoad("t", schema), NewConstant(types.NewBoolean(false)))
+	if err != nil {
+		t.Fatalf("NewSelect() = %v, want nil", err)
+	}
+	aggregates := []AggregateCall{
+		{Name: "count(*)", Function: AggregateCount},
+		{Name: "sum(a)", Function: AggregateSum, Argument: a},
+	}
+
+	grouped, err := NewAggregate(empty, []OutputColumn{SimpleColumn("a", 0, types.TypeDecimal)}, aggregates)
+	if err != nil {
+		t.Fatalf("NewAggregate() = %v, want nil", err)
+	}
+	if got := run(t, grouped, db).Rows; len(got) != 0 {
+		t.Errorf("grouped rows = %v, want none", got)
+	}
+
+	total, err := NewAggregate(empty, nil, aggregates)
+	if err != nil {
+		t.Fatalf("NewAggregate() = %v, want nil", err)
+	}
+	want := [][]types.Value{{types.NewDecimal("0"), types.NewNull(types.TypeDecimal)}}
+	if got := run(t, total, db).Rows; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestNewAggregateInvalid(t *testing.T) {
+	_, schema := sortDatabase(t)
+	b := NewColumnReference(1, types.TypeText)
+
+	cases := map[string]AggregateCall{
+		"sum of text":       {Function: AggregateSum, Argument: b},
+		"avg of text":       {Function: AggregateAvg, Argument: b},
+		"min without input": {Function: AggregateMin},
+		"unknown column":    {Function: AggregateMax, Argument: NewColumnReference(5, types.TypeDecimal)},
+	}
+	for name, call := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewAggregate(NewLoad("t", schema), nil, []AggregateCall{call}); err == nil {
+				t.Fatalf("NewAggregate() = nil, want error")
+			}
+		})
+	}
+}
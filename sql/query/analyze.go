@@ -0,0 +1,46 @@
+package query
+
+import (
+	"context"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+)
+
+// Analyze collects the statistics of a table, or of every table when Table is empty, and stores them in the database.
+// The statistics replace those of the previous ANALYZE and can be read from the storage statistics tables.
+type Analyze struct {
+	Table string
+}
+
+func NewAnalyze(table string) *Analyze {
+	return &Analyze{Table: table}
+}
+
+func (a *Analyze) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	tables := db.TableNames()
+	if a.Table != "" {
+		tables = []string{a.Table}
+	}
+	for _, name := range tables {
+		if err := checkCanceled(ctx); err != nil {
+			return 0, err
+		}
+		table, err := db.GetTable(name)
+		if err != nil {
+			return 0, err
+		}
+		if err := db.SetStatistics(name, storage.ComputeStatistics(table)); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
+func (a *Analyze) Print(printer *Printer) {
+	printer.Println("Analyze {")
+	printer.Indent()
+	if a.Table != "" {
+		printer.Println("Table: %q", a.Table)
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
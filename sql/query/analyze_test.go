@@ -0,0 +1,102 @@
+package query
+
+import (
+	"context"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	db, _ := insertDatabase(t)
+	insertRows(t, db, [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+		{types.NewDecimal("2"), types.NewText("b"), types.NewDecimal("5")},
+		{types.NewDecimal("3"), types.NewNull(types.TypeText), types.NewDecimal("5")},
+		{types.NewDecimal("4"), types.NewText("a"), types.NewDecimal("7")},
+	})
+	if _, err := NewAnalyze("t").Execute(context.Background(), db); err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+
+	decimal, text := types.NewDecimal, types.NewText
+	bucket := func(lower, upper types.Value, count, distinct int) storage.Bucket {
+		return storage.Bucket{Lower: lower, Upper: upper, Count: count, DistinctCount: distinct}
+	}
+	want := &storage.TableStatistics{
+		RowCount: 4,
+		Columns: []storage.ColumnStatistics{
+			{
+				Name:          "id",
+				DistinctCount: 4,
+				Min:           decimal("1"),
+				Max:           decimal("4"),
+				Histogram: []storage.Bucket{
+					bucket(decimal("1"), decimal("1"), 1, 1),
+					bucket(decimal("2"), decimal("2"), 1, 1),
+					bucket(decimal("3"), decimal("3"), 1, 1),
+					bucket(decimal("4"), decimal("4"), 1, 1),
+				},
+			},
+			{
+				// all the equal values are counted in a single bucket
+				Name:          "name",
+				NullFraction:  0.25,
+				DistinctCount: 2,
+				Min:           text("a"),
+				Max:           text("b"),
+				Histogram:     []storage.Bucket{bucket(text("a"), text("a"), 2, 1), bucket(text("b"), text("b"), 1, 1)},
+			},
+			{
+				Name:          "score",
+				DistinctCount: 2,
+				Min:           decimal("5"),
+				Max:           decimal("7"),
+				Histogram:     []storage.Bucket{bucket(decimal("5"), decimal("5"), 3, 1), bucket(decimal("7"), decimal("7"), 1, 1)},
+			},
+		},
+	}
+	got, ok := db.GetStatistics("t")
+	if !ok {
+		t.Fatalf("db.GetStatistics() found no statistics after ANALYZE")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("statistics = %+v, want %+v", got, want)
+	}
+}
+
+func TestAnalyzeHistogramBuckets(t *testing.T) {
+	db, _ := insertDatabase(t)
+	var rows [][]types.Value
+	for i := 0; i < 25; i++ {
+		rows = append(rows, []types.Value{types.NewDecimal(strconv.Itoa(i)), types.NewNull(types.TypeText), types.NewDecimal("1")})
+	}
+	insertRows(t, db, rows)
+	// every table is analyzed without a table name
+	if _, err := NewAnalyze("").Execute(context.Background(), db); err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	statistics, _ := db.GetStatistics("t")
+
+	id := statistics.Columns[0]
+	if len(id.Histogram) != storage.HistogramBuckets {
+		t.Fatalf("got %d buckets, want %d", len(id.Histogram), storage.HistogramBuckets)
+	}
+	total := 0
+	for _, b := range id.Histogram {
+		if b.Count < 2 || b.Count > 3 {
+			t.Errorf("bucket %v..%v has %d values, want 2 or 3", b.Lower, b.Upper, b.Count)
+		}
+		total += b.Count
+	}
+	if total != len(rows) {
+		t.Errorf("buckets hold %d values, want %d", total, len(rows))
+	}
+
+	name := statistics.Columns[1]
+	if name.NullFraction != 1 || name.Min != nil || name.Max != nil || len(name.Histogram) != 0 {
+		t.Errorf("statistics of a NULL column = %+v, want a null fraction of 1 and no values", name)
+	}
+}
@@ -0,0 +1,169 @@
+package query
+
+import (
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+type ArithmeticOperator int
+
+const (
+	ArithmeticOperatorAdd ArithmeticOperator = iota
+	ArithmeticOperatorSub
+	ArithmeticOperatorMul
+	ArithmeticOperatorDiv
+	ArithmeticOperatorMod
+)
+
+func (a ArithmeticOperator) String() string {
+	switch a {
+	case ArithmeticOperatorAdd:
+		return "add"
+	case ArithmeticOperatorSub:
+		return "sub"
+	case ArithmeticOperatorMul:
+		return "mul"
+	case ArithmeticOperatorDiv:
+		return "div"
+	case ArithmeticOperatorMod:
+		return "mod"
+	}
+	return "unknown"
+}
+
+// ArithmeticOperation computes a decimal from two decimal operands. Division keeps DivisionDigits
+// fractional digits and rounds with Rounding, every other operator is exact.
+type ArithmeticOperation struct {
+	Left           Expression
+	Right          Expression
+	Operator       ArithmeticOperator
+	DivisionDigits int
+	Rounding       types.RoundingMode
+}
+
+func NewArithmeticOperation(left, right Expression, operator ArithmeticOperator) (*ArithmeticOperation, error) {
+	for _, operand := range []Expression{left, right} {
+		if operand.Type() != types.TypeDecimal {
+			return nil, fmt.Errorf("operand of %s must be a decimal expression, got %v: %s", operator, operand.Type(), operand)
+		}
+	}
+	return &ArithmeticOperation{
+		Left:           left,
+		Right:          right,
+		Operator:       operator,
+		DivisionDigits: types.DefaultDivisionDigits,
+		Rounding:       types.RoundHalfEven,
+	}, nil
+}
+
+func (a *ArithmeticOperation) Type() types.Type {
+	return types.TypeDecimal
+}
+
+func (a *ArithmeticOperation) Evaluate(t *types.Row) (types.Value, error) {
+	left, err := a.Left.Evaluate(t)
+	if err != nil {
+		return nil, err
+	}
+	right, err := a.Right.Evaluate(t)
+	if err != nil {
+		return nil, err
+	}
+	if types.IsNull(left) || types.IsNull(right) {
+		return types.NewNull(types.TypeDecimal), nil
+	}
+	l, lok := left.(types.Decimal)
+	r, rok := right.(types.Decimal)
+	if !lok || !rok {
+		return nil, fmt.Errorf("%s: operator %s requires decimal operands, got %v and %v", a, a.Operator, left, right)
+	}
+
+	var result types.Decimal
+	switch a.Operator {
+	case ArithmeticOperatorAdd:
+		return l.Add(r), nil
+	case ArithmeticOperatorSub:
+		return l.Sub(r), nil
+	case ArithmeticOperatorMul:
+		return l.Mul(r), nil
+	case ArithmeticOperatorDiv:
+		result, err = l.Div(r, a.DivisionDigits, a.Rounding)
+	case ArithmeticOperatorMod:
+		result, err = l.Mod(r)
+	default:
+		return nil, fmt.Errorf("%s: unsupported operator %v", a, a.Operator)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", a, err)
+	}
+	return result, nil
+}
+
+func (a *ArithmeticOperation) Check(schema types.TableSchema) error {
+	if err := a.Left.Check(schema); err != nil {
+		return err
+	}
+	return a.Right.Check(schema)
+}
+
+func (a *ArithmeticOperation) String() string {
+	return fmt.Sprintf("ArithmeticOperation(%s %s %s)", a.Left, a.Operator, a.Right)
+}
+
+func (a *ArithmeticOperation) Children() []Expression {
+	return []Expression{a.Left, a.Right}
+}
+
+func (a *ArithmeticOperation) Attributes() []Attribute {
+	attributes := []Attribute{{Name: "Operator", Value: a.Operator.String()}}
+	if a.Operator == ArithmeticOperatorDiv {
+		attributes = append(attributes,
+			Attribute{Name: "DivisionDigits", Value: a.DivisionDigits},
+			Attribute{Name: "Rounding", Value: a.Rounding.String()})
+	}
+	return attributes
+}
+
+// Negation is the unary minus of a decimal expression
+type Negation struct {
+	Expression Expression
+}
+
+func NewNegation(expression Expression) (*Negation, error) {
+	if expression.Type() != types.TypeDecimal {
+		return nil, fmt.Errorf("operand of unary minus must be a decimal expression, got %v: %s", expression.Type(), expression)
+	}
+	return &Negation{Expression: expression}, nil
+}
+
+func (n *Negation) Type() types.Type {
+	return types.TypeDecimal
+}
+
+func (n *Negation) Evaluate(t *types.Row) (types.Value, error) {
+	value, err := n.Expression.Evaluate(t)
+	if err != nil || types.IsNull(value) {
+		return value, err
+	}
+	d, ok := value.(types.Decimal)
+	if !ok {
+		return nil, fmt.Errorf("%s: unary minus requires a decimal operand, got %v", n, value)
+	}
+	return d.Neg(), nil
+}
+
+func (n *Negation) Check(schema types.TableSchema) error {
+	return n.Expression.Check(schema)
+}
+
+func (n *Negation) String() string {
+	return fmt.Sprintf("Negation(%s)", n.Expression)
+}
+
+func (n *Negation) Children() []Expression {
+	return []Expression{n.Expression}
+}
+
+func (n *Negation) Attributes() []Attribute {
+	return nil
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"testing"
+)
+
+func TestArithmeticOperation(t *testing.T) {
+	decimal := func(s string) Expression {
+		return NewConstant(types.NewDecimal(s))
+	}
+	null := NewConstant(types.NewNull(types.TypeDecimal))
+
+	cases := []struct {
+		left, right Expression
+		operator    ArithmeticOperator
+		want        types.Value
+	}{
+		{left: decimal("1.5"), right: decimal("2"), operator: ArithmeticOperatorAdd, want: types.NewDecimal("3.5")},
+		{left: decimal("1.5"), right: decimal("2"), operator: ArithmeticOperatorSub, want: types.NewDecimal("-0.5")},
+		{left: decimal("1.5"), right: decimal("2"), operator: ArithmeticOperatorMul, want: types.NewDecimal("3")},
+		{left: decimal("1"), right: decimal("8"), operator: ArithmeticOperatorDiv, want: types.NewDecimal("0.125")},
+		{left: decimal("2"), right: decimal("3"), operator: ArithmeticOperatorDiv, want: types.NewDecimal("0.6666666666666667")},
+		{left: decimal("7"), right: decimal("3"), operator: ArithmeticOperatorMod, want: types.NewDecimal("1")},
+		{left: decimal("7"), right: null, operator: ArithmeticOperatorAdd, want: types.NewNull(types.TypeDecimal)},
+	}
+
+	for _, c := range cases {
+		expr, err := NewArithmeticOperation(c.left, c.right, c.operator)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Run(expr.String(), func(t *testing.T) {
+			if expr.Type() != types.TypeDecimal {
+				t.Errorf("%s.Type() = %v, want %v", expr, expr.Type(), types.TypeDecimal)
+			}
+			got, err := expr.Evaluate(sampleRow())
+			if err != nil {
+				t.Fatalf("%s.Evaluate() = %v, want nil", expr, err)
+			}
+			if got.String() != c.want.String() {
+				t.Errorf("%s.Evaluate() = %v, want %v", expr, got, c.want)
+			}
+		})
+	}
+
+	if _, err := NewArithmeticOperation(decimal("1"), NewColumnReference(1, types.TypeText), ArithmeticOperatorAdd); err == nil {
+		t.Errorf("NewArithmeticOperation() with text operand returned nil, want error")
+	}
+}
+
+func TestNegation(t *testing.T) {
+	expr, err := NewNegation(NewConstant(types.NewDecimal("1.5")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, err := expr.Evaluate(sampleRow()); err != nil || got.String() != "-1.5" {
+		t.Errorf("%s.Evaluate() = %v, %v, want -1.5", expr, got, err)
+	}
+	if _, err := NewNegation(NewColumnReference(0, types.TypeBoolean)); err == nil {
+		t.Errorf("NewNegation() with boolean operand returned nil, want error")
+	}
+}
@@ -0,0 +1,272 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// Command is a planned statement that modifies the database instead of returning rows
+type Command interface {
+	// Execute applies the statement and returns the number of affected rows
+	Execute(ctx context.Context, db *storage.Database) (int, error)
+	Print(printer *Printer)
+}
+
+// Insert stores the rows produced by Source in a table. The columns of Source are stored in the
+// table columns given by Columns, every other column is set to its default value or NULL.
+type Insert struct {
+	Table       string
+	TableSchema types.TableSchema
+	Columns     []int
+	Source      QueryPlan
+}
+
+func NewInsert(table string, schema types.TableSchema, columns []int, source QueryPlan) (*Insert, error) {
+	sourceSchema := source.Schema()
+	if len(sourceSchema.Columns) != len(columns) {
+		return nil, fmt.Errorf("INSERT has %d target columns but %d values", len(columns), len(sourceSchema.Columns))
+	}
+	targeted := make([]bool, len(schema.Columns))
+	for i, index := range columns {
+		if index < 0 || index >= len(schema.Columns) {
+			return nil, fmt.Errorf("column %d does not exist in table %s", index, table)
+		}
+		column := schema.Columns[index]
+		if targeted[index] {
+			return nil, fmt.Errorf("column %s is specified more than once", column.Name)
+		}
+		targeted[index] = true
+		if t := sourceSchema.Columns[i].Type; t != column.Type && t != types.TypeNull {
+			return nil, fmt.Errorf("mismatched types: column %s is of type %v, got %v", column.Name, column.Type, t)
+		}
+	}
+	for i, column := range schema.Columns {
+		if !targeted[i] && column.Default == nil && !column.Nullable {
+			return nil, fmt.Errorf("column %s does not allow NULL values and has no default", column.Name)
+		}
+	}
+	return &Insert{Table: table, TableSchema: schema, Columns: columns, Source: source}, nil
+}
+
+// Execute checks every row before storing any of them, so that a failing INSERT leaves the table unchanged
+func (i *Insert) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	table, err := db.GetTable(i.Table)
+	if err != nil {
+		return 0, err
+	}
+	source, err := i.Source.Run(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := make([][]types.Value, len(source.Rows))
+	for r, values := range source.Rows {
+		row := make([]types.Value, len(table.Schema.Columns))
+		for c, column := range table.Schema.Columns {
+			row[c] = column.Default
+			if row[c] == nil {
+				row[c] = types.NewNull(column.Type)
+			}
+		}
+		for c, index := range i.Columns {
+			row[index] = values[c]
+			if types.IsNull(values[c]) {
+				// an untyped NULL is stored with the type of its column
+				row[index] = types.NewNull(table.Schema.Columns[index].Type)
+			}
+		}
+		if err := table.Schema.Check(row); err != nil {
+			return 0, err
+		}
+		rows[r] = row
+	}
+
+	for _, row := range rows {
+		if err := table.Insert(row); err != nil {
+			return 0, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (i *Insert) Print(printer *Printer) {
+	printer.Println("Insert {")
+	printer.Indent()
+	printer.Println("Table: %q", i.Table)
+	names := make([]string, len(i.Columns))
+	for c, index := range i.Columns {
+		names[c] = i.TableSchema.Columns[index].Name
+	}
+	printer.Println("Columns: %v", names)
+	printer.Println("Source:")
+	i.Source.Print(printer)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+// Assignment sets the column at index Column to the value of Expression
+type Assignment struct {
+	Column     int
+	Expression Expression
+}
+
+// Update evaluates the assignments for every row of a table satisfying Condition, a nil Condition matches every row.
+// Expressions are evaluated against the row before any assignment, and are given the table schema prefixed with its name.
+type Update struct {
+	Table       string
+	TableSchema types.TableSchema
+	Assignments []Assignment
+	Condition   Expression
+}
+
+func NewUpdate(table string, schema types.TableSchema, assignments []Assignment, condition Expression) (*Update, error) {
+	if err := checkCondition(schema, condition); err != nil {
+		return nil, err
+	}
+	assigned := make([]bool, len(schema.Columns))
+	for _, a := range assignments {
+		if a.Column < 0 || a.Column >= len(schema.Columns) {
+			return nil, fmt.Errorf("column %d does not exist in table %s", a.Column, table)
+		}
+		column := schema.Columns[a.Column]
+		if assigned[a.Column] {
+			return nil, fmt.Errorf("column %s is assigned more than once", column.Name)
+		}
+		assigned[a.Column] = true
+		if err := a.Expression.Check(schema); err != nil {
+			return nil, err
+		}
+		if t := a.Expression.Type(); t != column.Type && t != types.TypeNull {
+			return nil, fmt.Errorf("mismatched types: column %s is of type %v, got %v: %s", column.Name, column.Type, t, a.Expression)
+		}
+	}
+	return &Update{Table: table, TableSchema: schema, Assignments: assignments, Condition: condition}, nil
+}
+
+// Execute checks every updated row before changing any of them, so that a failing UPDATE leaves the table unchanged
+func (u *Update) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	table, err := db.GetTable(u.Table)
+	if err != nil {
+		return 0, err
+	}
+
+	var indexes []int
+	var updated [][]types.Value
+	for i := range table.Rows {
+		if err := checkCanceled(ctx); err != nil {
+			return 0, err
+		}
+		row := &types.Row{Schema: u.TableSchema, Values: table.Rows[i]}
+		if u.Condition != nil {
+			ok, err := isTrue(u.Condition, row)
+			if err != nil {
+				return 0, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		values := append([]types.Value{}, row.Values...)
+		for _, a := range u.Assignments {
+			value, err := a.Expression.Evaluate(row)
+			if err != nil {
+				return 0, fmt.Errorf("column %s: %w", table.Schema.Columns[a.Column].Name, err)
+			}
+			if types.IsNull(value) {
+				value = types.NewNull(table.Schema.Columns[a.Column].Type)
+			}
+			values[a.Column] = value
+		}
+		if err := table.Schema.Check(values); err != nil {
+			return 0, err
+		}
+		indexes = append(indexes, i)
+		updated = append(updated, values)
+	}
+
+	for i, index := range indexes {
+		if err := table.Update(index, updated[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(indexes), nil
+}
+
+func (u *Update) Print(printer *Printer) {
+	printer.Println("Update {")
+	printer.Indent()
+	printer.Println("Table: %q", u.Table)
+	printer.Println("Assignments:")
+	printer.Indent()
+	for _, a := range u.Assignments {
+		printer.Println("%s: %s", u.TableSchema.Columns[a.Column].Name, a.Expression)
+	}
+	printer.Dedent()
+	if u.Condition != nil {
+		printer.Println("Condition: %s", u.Condition)
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+// Delete removes the rows of a table satisfying Condition, a nil Condition matches every row
+type Delete struct {
+	Table       string
+	TableSchema types.TableSchema
+	Condition   Expression
+}
+
+func NewDelete(table string, schema types.TableSchema, condition Expression) (*Delete, error) {
+	if err := checkCondition(schema, condition); err != nil {
+		return nil, err
+	}
+	return &Delete{Table: table, TableSchema: schema, Condition: condition}, nil
+}
+
+func (d *Delete) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	table, err := db.GetTable(d.Table)
+	if err != nil {
+		return 0, err
+	}
+	var indexes []int
+	for i := range table.Rows {
+		if err := checkCanceled(ctx); err != nil {
+			return 0, err
+		}
+		ok := true
+		if d.Condition != nil {
+			ok, err = isTrue(d.Condition, &types.Row{Schema: d.TableSchema, Values: table.Rows[i]})
+			if err != nil {
+				return 0, err
+			}
+		}
+		if ok {
+			indexes = append(indexes, i)
+		}
+	}
+	table.Delete(indexes)
+	return len(indexes), nil
+}
+
+func (d *Delete) Print(printer *Printer) {
+	printer.Println("Delete {")
+	printer.Indent()
+	printer.Println("Table: %q", d.Table)
+	if d.Condition != nil {
+		printer.Println("Condition: %s", d.Condition)
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func checkCondition(schema types.TableSchema, condition Expression) error {
+	if condition == nil {
+		return nil
+	}
+	if condition.Type() != types.TypeBoolean {
+		return fmt.Errorf("condition must be a boolean expression %v", condition)
+	}
+	return condition.Check(schema)
+}
@@ -0,0 +1,247 @@
+package query
+
+import (
+	"context"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func insertDatabase(t *testing.T) (*storage.Database, types.TableSchema) {
+	t.Helper()
+	schema := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "id", Type: types.TypeDecimal},
+			{Name: "name", Type: types.TypeText, Nullable: true},
+			{Name: "score", Type: types.TypeDecimal, Default: types.NewDecimal("10")},
+		},
+	}
+	db := storage.NewDatabase()
+	if _, err := db.CreateTable("t", schema); err != nil {
+		t.Fatalf("db.CreateTable() = %v, want nil", err)
+	}
+	return db, schema
+}
+
+func TestInsert(t *testing.T) {
+	db, schema := insertDatabase(t)
+	values, err := NewValues(
+		types.TableSchema{Columns: []types.ColumnSchema{schema.Columns[0]}},
+		[][]Expression{
+			{NewConstant(types.NewDecimal("1"))},
+			{NewConstant(types.NewDecimal("2"))},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewValues() = %v, want nil", err)
+	}
+	insert, err := NewInsert("t", schema, []int{0}, values)
+	if err != nil {
+		t.Fatalf("NewInsert() = %v, want nil", err)
+	}
+	count, err := insert.Execute(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	if count != 2 {
+		t.Errorf("Execute() = %d, want 2", count)
+	}
+
+	table, _ := db.GetTable("t")
+	want := [][]types.Value{
+		{types.NewDecimal("1"), types.NewNull(types.TypeText), types.NewDecimal("10")},
+		{types.NewDecimal("2"), types.NewNull(types.TypeText), types.NewDecimal("10")},
+	}
+	if !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("rows = %v, want %v", table.Rows, want)
+	}
+}
+
+func TestInsertFailureLeavesTableUnchanged(t *testing.T) {
+	db, schema := insertDatabase(t)
+	values, err := NewValues(
+		types.TableSchema{Columns: []types.ColumnSchema{schema.Columns[0]}},
+		[][]Expression{
+			{NewConstant(types.NewDecimal("1"))},
+			{NewConstant(types.NewNull(types.TypeDecimal))},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewValues() = %v, want nil", err)
+	}
+	insert, err := NewInsert("t", schema, []int{0}, values)
+	if err != nil {
+		t.Fatalf("NewInsert() = %v, want nil", err)
+	}
+	if _, err := insert.Execute(context.Background(), db); err == nil {
+		t.Fatalf("Execute() = nil, want error")
+	}
+	if table, _ := db.GetTable("t"); len(table.Rows) != 0 {
+		t.Errorf("rows = %v, want none", table.Rows)
+	}
+}
+
+func TestNewInsertInvalid(t *testing.T) {
+	_, schema := insertDatabase(t)
+	text := types.TableSchema{Columns: []types.ColumnSchema{{Name: "x", Type: types.TypeText}}}
+	decimals := types.TableSchema{Columns: []types.ColumnSchema{
+		{Name: "x", Type: types.TypeDecimal},
+		{Name: "y", Type: types.TypeDecimal},
+	}}
+
+	cases := []struct {
+		name    string
+		columns []int
+		source  types.TableSchema
+	}{
+		{name: "wrong number of columns", columns: []int{0}, source: decimals},
+		{name: "mismatched types", columns: []int{0}, source: text},
+		{name: "duplicate column", columns: []int{0, 0}, source: decimals},
+		{name: "missing column without default", columns: []int{2}, source: types.TableSchema{Columns: decimals.Columns[:1]}},
+		{name: "unknown column", columns: []int{5}, source: types.TableSchema{Columns: decimals.Columns[:1]}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			source, err := NewValues(c.source, nil)
+			if err != nil {
+				t.Fatalf("NewValues() = %v, want nil", err)
+			}
+			if _, err := NewInsert("t", schema, c.columns, source); err == nil {
+				t.Fatalf("NewInsert() = nil, want error")
+			}
+		})
+	}
+}
+
+func insertRows(t *testing.T, db *storage.Database, rows [][]types.Value) *types.Relation {
+	t.Helper()
+	table, err := db.GetTable("t")
+	if err != nil {
+		t.Fatalf("db.GetTable() = %v, want nil", err)
+	}
+	for _, row := range rows {
+		if err := table.Insert(row); err != nil {
+			t.Fatalf("table.Insert() = %v, want nil", err)
+		}
+	}
+	return table
+}
+
+func TestUpdate(t *testing.T) {
+	db, schema := insertDatabase(t)
+	table := insertRows(t, db, [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+		{types.NewDecimal("2"), types.NewText("b"), types.NewDecimal("6")},
+		{types.NewDecimal("3"), types.NewNull(types.TypeText), types.NewDecimal("7")},
+	})
+	id := NewColumnReference(0, types.TypeDecimal)
+	score := NewColumnReference(2, types.TypeDecimal)
+	condition, err := NewBinaryOperation(id, NewConstant(types.NewDecimal("1")), BinaryOperatorGt)
+	if err != nil {
+		t.Fatalf("NewBinaryOperation() = %v, want nil", err)
+	}
+	assignments := []Assignment{
+		// both assignments see the row before it is updated
+		{Column: 0, Expression: score},
+		{Column: 2, Expression: id},
+		{Column: 1, Expression: NewConstant(types.NewNull(types.TypeNull))},
+	}
+	update, err := NewUpdate("t", schema, assignments, condition)
+	if err != nil {
+		t.Fatalf("NewUpdate() = %v, want nil", err)
+	}
+	count, err := update.Execute(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	if count != 2 {
+		t.Errorf("Execute() = %d, want 2", count)
+	}
+	want := [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+		{types.NewDecimal("6"), types.NewNull(types.TypeText), types.NewDecimal("2")},
+		{types.NewDecimal("7"), types.NewNull(types.TypeText), types.NewDecimal("3")},
+	}
+	if !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("rows = %v, want %v", table.Rows, want)
+	}
+
+	// a failing UPDATE leaves the table unchanged
+	failing, err := NewUpdate("t", schema, []Assignment{{Column: 0, Expression: NewConstant(types.NewNull(types.TypeDecimal))}}, condition)
+	if err != nil {
+		t.Fatalf("NewUpdate() = %v, want nil", err)
+	}
+	if _, err := failing.Execute(context.Background(), db); err == nil {
+		t.Fatalf("Execute() = nil, want error")
+	}
+	if !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("rows = %v, want %v", table.Rows, want)
+	}
+}
+
+func TestNewUpdateInvalid(t *testing.T) {
+	_, schema := insertDatabase(t)
+	text := NewConstant(types.NewText("x"))
+	one := NewConstant(types.NewDecimal("1"))
+
+	cases := []struct {
+		name        string
+		assignments []Assignment
+		condition   Expression
+	}{
+		{name: "mismatched types", assignments: []Assignment{{Column: 0, Expression: text}}},
+		{name: "duplicate column", assignments: []Assignment{{Column: 0, Expression: one}, {Column: 0, Expression: one}}},
+		{name: "unknown column", assignments: []Assignment{{Column: 3, Expression: one}}},
+		{name: "unknown reference", assignments: []Assignment{{Column: 0, Expression: NewColumnReference(3, types.TypeDecimal)}}},
+		{name: "condition not boolean", assignments: []Assignment{{Column: 0, Expression: one}}, condition: one},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := NewUpdate("t", schema, c.assignments, c.condition); err == nil {
+				t.Fatalf("NewUpdate() = nil, want error")
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, schema := insertDatabase(t)
+	table := insertRows(t, db, [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+		{types.NewDecimal("2"), types.NewNull(types.TypeText), types.NewDecimal("6")},
+		{types.NewDecimal("3"), types.NewText("c"), types.NewDecimal("7")},
+	})
+	name := NewColumnReference(1, types.TypeText)
+	condition, err := NewBinaryOperation(name, NewConstant(types.NewText("a")), BinaryOperatorNe)
+	if err != nil {
+		t.Fatalf("NewBinaryOperation() = %v, want nil", err)
+	}
+
+	// rows where the condition is unknown are kept
+	del, err := NewDelete("t", schema, condition)
+	if err != nil {
+		t.Fatalf("NewDelete() = %v, want nil", err)
+	}
+	if count, err := del.Execute(context.Background(), db); err != nil || count != 1 {
+		t.Fatalf("Execute() = %d, %v, want 1, nil", count, err)
+	}
+	want := [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+		{types.NewDecimal("2"), types.NewNull(types.TypeText), types.NewDecimal("6")},
+	}
+	if !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("rows = %v, want %v", table.Rows, want)
+	}
+
+	all, err := NewDelete("t", schema, nil)
+	if err != nil {
+		t.Fatalf("NewDelete() = %v, want nil", err)
+	}
+	if count, err := all.Execute(context.Background(), db); err != nil || count != 2 {
+		t.Fatalf("Execute() = %d, %v, want 2, nil", count, err)
+	}
+	if len(table.Rows) != 0 {
+		t.Errorf("rows = %v, want none", table.Rows)
+	}
+}
@@ -0,0 +1,252 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+type CreateTable struct {
+	Name        string
+	TableSchema types.TableSchema
+	// IfNotExists turns creating a table that already exists into a no-op instead of an error
+	IfNotExists bool
+}
+
+func NewCreateTable(name string, schema types.TableSchema, ifNotExists bool) (*CreateTable, error) {
+	if len(schema.Columns) == 0 {
+		return nil, fmt.Errorf("table %s must have at least one column", name)
+	}
+	names := make(map[string]bool)
+	for _, column := range schema.Columns {
+		if names[column.Name] {
+			return nil, fmt.Errorf("duplicate column name %s", column.Name)
+		}
+		names[column.Name] = true
+		if column.Type == types.TypeNull {
+			return nil, fmt.Errorf("column %s must have a type", column.Name)
+		}
+		if column.Default != nil {
+			if err := column.Check(column.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value: %w", err)
+			}
+		}
+	}
+	return &CreateTable{Name: name, TableSchema: schema, IfNotExists: ifNotExists}, nil
+}
+
+func (c *CreateTable) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	if _, err := db.GetTable(c.Name); err == nil && c.IfNotExists {
+		return 0, nil
+	}
+	if _, err := db.CreateTable(c.Name, c.TableSchema); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (c *CreateTable) Print(printer *Printer) {
+	printer.Println("CreateTable {")
+	printer.Indent()
+	printer.Println("Table: %q", c.Name)
+	printer.Println("Schema: %s", &c.TableSchema)
+	if c.IfNotExists {
+		printer.Println("IfNotExists: true")
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+type DropTable struct {
+	Name string
+	// IfExists turns dropping a missing table into a no-op instead of an error
+	IfExists bool
+}
+
+func NewDropTable(name string, ifExists bool) *DropTable {
+	return &DropTable{Name: name, IfExists: ifExists}
+}
+
+func (d *DropTable) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	if _, err := db.GetTable(d.Name); err != nil && d.IfExists {
+		return 0, nil
+	}
+	if err := db.DropTable(d.Name); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (d *DropTable) Print(printer *Printer) {
+	printer.Println("DropTable {")
+	printer.Indent()
+	printer.Println("Table: %q", d.Name)
+	if d.IfExists {
+		printer.Println("IfExists: true")
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+// AddColumn appends a column to a table, existing rows get its default value or NULL
+type AddColumn struct {
+	Table  string
+	Column types.ColumnSchema
+}
+
+func NewAddColumn(table string, column types.ColumnSchema) (*AddColumn, error) {
+	if column.Default != nil {
+		if err := column.Check(column.Default); err != nil {
+			return nil, fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	return &AddColumn{Table: table, Column: column}, nil
+}
+
+func (a *AddColumn) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	table, err := db.GetTable(a.Table)
+	if err != nil {
+		return 0, err
+	}
+	if _, _, ok := table.Schema.GetColumn(a.Column.Name); ok {
+		return 0, fmt.Errorf("column %s already exists in table %s", a.Column.Name, a.Table)
+	}
+	value := a.Column.Default
+	if value == nil {
+		if !a.Column.Nullable && len(table.Rows) > 0 {
+			return 0, fmt.Errorf("column %s does not allow NULL values and has no default to fill the existing rows", a.Column.Name)
+		}
+		value = types.NewNull(a.Column.Type)
+	}
+
+	schema := types.TableSchema{Columns: append(append([]types.ColumnSchema{}, table.Schema.Columns...), a.Column)}
+	rows := make([][]types.Value, len(table.Rows))
+	for i, row := range table.Rows {
+		rows[i] = append(append([]types.Value{}, row...), value)
+	}
+	table.Schema, table.Rows = schema, rows
+	// the statistics no longer match the columns until the table is analyzed again
+	db.DeleteStatistics(a.Table)
+	return 0, nil
+}
+
+func (a *AddColumn) Print(printer *Printer) {
+	printer.Println("AddColumn {")
+	printer.Indent()
+	printer.Println("Table: %q", a.Table)
+	printer.Println("Column: %s", &a.Column)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+// DropColumn removes a column and its values from a table
+type DropColumn struct {
+	Table  string
+	Column string
+}
+
+func NewDropColumn(table, column string) *DropColumn {
+	return &DropColumn{Table: table, Column: column}
+}
+
+func (d *DropColumn) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	table, err := db.GetTable(d.Table)
+	if err != nil {
+		return 0, err
+	}
+	index, _, ok := table.Schema.GetColumn(d.Column)
+	if !ok {
+		return 0, fmt.Errorf("column %s not found in table %s", d.Column, d.Table)
+	}
+	if len(table.Schema.Columns) == 1 {
+		return 0, fmt.Errorf("cannot drop %s, the only column of table %s", d.Column, d.Table)
+	}
+
+	schema := types.TableSchema{Columns: without(table.Schema.Columns, index)}
+	rows := make([][]types.Value, len(table.Rows))
+	for i, row := range table.Rows {
+		rows[i] = without(row, index)
+	}
+	table.Schema, table.Rows = schema, rows
+	db.DeleteStatistics(d.Table)
+	return 0, nil
+}
+
+func (d *DropColumn) Print(printer *Printer) {
+	printer.Println("DropColumn {")
+	printer.Indent()
+	printer.Println("Table: %q", d.Table)
+	printer.Println("Column: %q", d.Column)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+// without returns a copy of the slice without the element at index i
+func without[T any](s []T, i int) []T {
+	result := make([]T, 0, len(s)-1)
+	result = append(result, s[:i]...)
+	return append(result, s[i+1:]...)
+}
+
+type RenameColumn struct {
+	Table string
+	From  string
+	To    string
+}
+
+func NewRenameColumn(table, from, to string) *RenameColumn {
+	return &RenameColumn{Table: table, From: from, To: to}
+}
+
+func (r *RenameColumn) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	table, err := db.GetTable(r.Table)
+	if err != nil {
+		return 0, err
+	}
+	index, _, ok := table.Schema.GetColumn(r.From)
+	if !ok {
+		return 0, fmt.Errorf("column %s not found in table %s", r.From, r.Table)
+	}
+	if _, _, ok := table.Schema.GetColumn(r.To); ok {
+		return 0, fmt.Errorf("column %s already exists in table %s", r.To, r.Table)
+	}
+
+	columns := append([]types.ColumnSchema{}, table.Schema.Columns...)
+	columns[index].Name = r.To
+	table.Schema = types.TableSchema{Columns: columns}
+	db.DeleteStatistics(r.Table)
+	return 0, nil
+}
+
+func (r *RenameColumn) Print(printer *Printer) {
+	printer.Println("RenameColumn {")
+	printer.Indent()
+	printer.Println("Table: %q", r.Table)
+	printer.Println("From: %q", r.From)
+	printer.Println("To: %q", r.To)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+type RenameTable struct {
+	From string
+	To   string
+}
+
+func NewRenameTable(from, to string) *RenameTable {
+	return &RenameTable{From: from, To: to}
+}
+
+func (r *RenameTable) Execute(ctx context.Context, db *storage.Database) (int, error) {
+	return 0, db.RenameTable(r.From, r.To)
+}
+
+func (r *RenameTable) Print(printer *Printer) {
+	printer.Println("RenameTable {")
+	printer.Indent()
+	printer.Println("From: %q", r.From)
+	printer.Println("To: %q", r.To)
+	printer.Dedent()
+	printer.Println("}")
+}
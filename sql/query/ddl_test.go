@@ -0,0 +1,151 @@
+package query
+
+import (
+	"context"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func TestCreateAndDropTable(t *testing.T) {
+	db := storage.NewDatabase()
+	schema := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "id", Type: types.TypeDecimal},
+			{Name: "name", Type: types.TypeText, Nullable: true, Default: types.NewText("unknown")},
+		},
+	}
+
+	create, err := NewCreateTable("t", schema, false)
+	if err != nil {
+		t.Fatalf("NewCreateTable() = %v, want nil", err)
+	}
+	if _, err := create.Execute(context.Background(), db); err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	table, err := db.GetTable("t")
+	if err != nil {
+		t.Fatalf("db.GetTable() = %v, want nil", err)
+	}
+	if !reflect.DeepEqual(table.Schema, schema) {
+		t.Errorf("schema = %v, want %v", &table.Schema, &schema)
+	}
+	if _, err := create.Execute(context.Background(), db); err == nil {
+		t.Errorf("Execute() = nil, want error for an existing table")
+	}
+	ifNotExists, _ := NewCreateTable("t", schema, true)
+	if _, err := ifNotExists.Execute(context.Background(), db); err != nil {
+		t.Errorf("Execute() = %v, want nil with IF NOT EXISTS", err)
+	}
+
+	drop := NewDropTable("t", false)
+	if _, err := drop.Execute(context.Background(), db); err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	if _, err := db.GetTable("t"); err == nil {
+		t.Errorf("db.GetTable() = nil, want error after DROP TABLE")
+	}
+	if _, err := drop.Execute(context.Background(), db); err == nil {
+		t.Errorf("Execute() = nil, want error for a missing table")
+	}
+	if _, err := NewDropTable("t", true).Execute(context.Background(), db); err != nil {
+		t.Errorf("Execute() = %v, want nil with IF EXISTS", err)
+	}
+}
+
+func TestNewCreateTableInvalid(t *testing.T) {
+	cases := map[string][]types.ColumnSchema{
+		"no columns":         nil,
+		"duplicate column":   {{Name: "x", Type: types.TypeText}, {Name: "x", Type: types.TypeDecimal}},
+		"untyped column":     {{Name: "x", Type: types.TypeNull}},
+		"mismatched default": {{Name: "x", Type: types.TypeText, Default: types.NewDecimal("1")}},
+	}
+	for name, columns := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewCreateTable("t", types.TableSchema{Columns: columns}, false); err == nil {
+				t.Fatalf("NewCreateTable() = nil, want error")
+			}
+		})
+	}
+}
+
+func TestAlterTable(t *testing.T) {
+	db, _ := insertDatabase(t)
+	table := insertRows(t, db, [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+		{types.NewDecimal("2"), types.NewNull(types.TypeText), types.NewDecimal("6")},
+	})
+
+	commands := []Command{
+		NewDropColumn("t", "name"),
+		NewRenameColumn("t", "score", "points"),
+	}
+	added, err := NewAddColumn("t", types.ColumnSchema{Name: "active", Type: types.TypeBoolean, Default: types.NewBoolean(true)})
+	if err != nil {
+		t.Fatalf("NewAddColumn() = %v, want nil", err)
+	}
+	commands = append(commands, added, NewRenameTable("t", "u"))
+	for _, command := range commands {
+		if _, err := command.Execute(context.Background(), db); err != nil {
+			t.Fatalf("Execute() = %v, want nil", err)
+		}
+	}
+
+	renamed, err := db.GetTable("u")
+	if err != nil {
+		t.Fatalf("db.GetTable() = %v, want nil", err)
+	}
+	if renamed != table {
+		t.Errorf("renamed table is a different relation")
+	}
+	want := &types.Relation{
+		Schema: types.TableSchema{Columns: []types.ColumnSchema{
+			{Name: "id", Type: types.TypeDecimal},
+			{Name: "points", Type: types.TypeDecimal, Default: types.NewDecimal("10")},
+			{Name: "active", Type: types.TypeBoolean, Default: types.NewBoolean(true)},
+		}},
+		Rows: [][]types.Value{
+			{types.NewDecimal("1"), types.NewDecimal("5"), types.NewBoolean(true)},
+			{types.NewDecimal("2"), types.NewDecimal("6"), types.NewBoolean(true)},
+		},
+	}
+	if !reflect.DeepEqual(renamed, want) {
+		t.Errorf("table = %v, want %v", renamed, want)
+	}
+}
+
+func TestAlterTableInvalid(t *testing.T) {
+	db, schema := insertDatabase(t)
+	table := insertRows(t, db, [][]types.Value{
+		{types.NewDecimal("1"), types.NewText("a"), types.NewDecimal("5")},
+	})
+	if _, err := db.CreateTable("other", schema); err != nil {
+		t.Fatalf("db.CreateTable() = %v, want nil", err)
+	}
+	want := &types.Relation{Schema: table.Schema, Rows: [][]types.Value{table.Rows[0]}}
+
+	cases := map[string]Command{
+		"add existing column":        &AddColumn{Table: "t", Column: types.ColumnSchema{Name: "id", Type: types.TypeDecimal}},
+		"add not null without value": &AddColumn{Table: "t", Column: types.ColumnSchema{Name: "x", Type: types.TypeDecimal}},
+		"add to missing table":       &AddColumn{Table: "missing", Column: types.ColumnSchema{Name: "x", Type: types.TypeDecimal, Nullable: true}},
+		"drop missing column":        NewDropColumn("t", "missing"),
+		"rename missing column":      NewRenameColumn("t", "missing", "x"),
+		"rename onto existing":       NewRenameColumn("t", "id", "name"),
+		"rename onto existing table": NewRenameTable("t", "other"),
+	}
+	for name, command := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := command.Execute(context.Background(), db); err == nil {
+				t.Fatalf("Execute() = nil, want error")
+			}
+			if !reflect.DeepEqual(table, want) {
+				t.Errorf("table = %v, want %v", table, want)
+			}
+		})
+	}
+
+	if _, err := NewAddColumn("t", types.ColumnSchema{Name: "x", Type: types.TypeText, Default: types.NewDecimal("1")}); err == nil {
+		t.Errorf("NewAddColumn() = nil, want error for a mismatched default")
+	}
+}
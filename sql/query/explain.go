@@ -0,0 +1,193 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"strings"
+	"time"
+)
+
+// Explain returns the plan of a query as rows of text, one per line printed by the plan.
+// With Analyze the query is run first, its rows are discarded and every node of the plan is
+// annotated with the rows it produced, the number of times it was opened, the time spent in it,
+// including the time spent in its inputs, and the peak memory used by the rows it kept.
+type Explain struct {
+	Plan    QueryPlan
+	Analyze bool
+}
+
+func NewExplain(plan QueryPlan, analyze bool) *Explain {
+	return &Explain{Plan: plan, Analyze: analyze}
+}
+
+func (e *Explain) Schema() types.TableSchema {
+	return types.TableSchema{Columns: []types.ColumnSchema{{Name: "plan", Type: types.TypeText}}}
+}
+
+func (e *Explain) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	plan := e.Plan
+	if e.Analyze {
+		plan = analyze(plan)
+		iterator, err := plan.Open(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		defer iterator.Close()
+		for {
+			row, err := iterator.Next()
+			if err != nil {
+				return nil, err
+			}
+			if row == nil {
+				break
+			}
+		}
+	}
+
+	printer := NewPrinter()
+	plan.Print(printer)
+	lines := strings.Split(strings.TrimSuffix(printer.String(), "\n"), "\n")
+	rows := make([][]types.Value, len(lines))
+	for i, line := range lines {
+		rows[i] = []types.Value{types.NewText(line)}
+	}
+	return &sliceIterator{ctx: ctx, rows: rows}, nil
+}
+
+func (e *Explain) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, e, db)
+}
+
+func (e *Explain) Print(printer *Printer) {
+	printer.Println("Explain {")
+	printer.Indent()
+	if e.Analyze {
+		printer.Println("Analyze: true")
+	}
+	printer.Println("Plan:")
+	e.Plan.Print(printer)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (e *Explain) Children() []QueryPlan {
+	return []QueryPlan{e.Plan}
+}
+
+func (e *Explain) Attributes() []Attribute {
+	return []Attribute{{Name: "Analyze", Value: e.Analyze}}
+}
+
+// analyze returns a copy of a plan in which every node records its statistics while it runs
+func analyze(plan QueryPlan) QueryPlan {
+	children := plan.Children()
+	if len(children) > 0 {
+		analyzed := make([]QueryPlan, len(children))
+		for i, child := range children {
+			analyzed[i] = analyze(child)
+		}
+		plan = WithChildren(plan, analyzed)
+	}
+	return &analyzedPlan{QueryPlan: plan}
+}
+
+// analyzedPlan wraps a node of a plan run by EXPLAIN ANALYZE
+type analyzedPlan struct {
+	QueryPlan
+	rows    int
+	loops   int
+	elapsed time.Duration
+	memory  int
+}
+
+func (a *analyzedPlan) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	start := time.Now()
+	from, err := a.QueryPlan.Open(ctx, db)
+	a.elapsed += time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+	a.loops++
+	iterator := &analyzedIterator{from: from, plan: a}
+	iterator.measureMemory()
+	return iterator, nil
+}
+
+func (a *analyzedPlan) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, a, db)
+}
+
+// Print adds the statistics below the first line printed by the node, which names it
+func (a *analyzedPlan) Print(printer *Printer) {
+	node := &Printer{builder: new(strings.Builder), indentation: printer.indentation}
+	a.QueryPlan.Print(node)
+	first, rest, _ := strings.Cut(node.String(), "\n")
+	printer.builder.WriteString(first + "\n")
+	printer.Indent()
+	printer.Println("Actual: rows=%d loops=%d time=%s memory=%s", a.rows, a.loops, a.elapsed, formatBytes(a.memory))
+	printer.Dedent()
+	printer.builder.WriteString(rest)
+}
+
+type analyzedIterator struct {
+	from RowIterator
+	plan *analyzedPlan
+}
+
+func (a *analyzedIterator) Next() ([]types.Value, error) {
+	start := time.Now()
+	row, err := a.from.Next()
+	a.plan.elapsed += time.Since(start)
+	if row != nil {
+		a.plan.rows++
+	}
+	a.measureMemory()
+	return row, err
+}
+
+func (a *analyzedIterator) Close() {
+	a.from.Close()
+}
+
+func (a *analyzedIterator) measureMemory() {
+	if m, ok := a.from.(memoryUser); ok {
+		a.plan.memory = max(a.plan.memory, m.memory())
+	}
+}
+
+// memoryUser is implemented by the iterators that keep rows in memory, memory returns an estimate of
+// the bytes used by those rows
+type memoryUser interface {
+	memory() int
+}
+
+// rowsMemory estimates the bytes used by rows, counting the slices, the values and the text they hold
+func rowsMemory(rows [][]types.Value) int {
+	size := 0
+	for _, row := range rows {
+		size += 24 + 16*len(row)
+		for _, value := range row {
+			switch v := value.(type) {
+			case types.Text:
+				size += 16 + len(v.Value())
+			case types.Decimal:
+				size += 48
+			default:
+				size += 8
+			}
+		}
+	}
+	return size
+}
+
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fkB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -0,0 +1,86 @@
+package query
+
+import (
+	"context"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	db, schema := sortDatabase(t)
+	sort, err := NewSort(NewLoad("t", schema), []SortKey{{Expression: NewColumnReference(1, types.TypeText)}})
+	if err != nil {
+		t.Fatalf("NewSort() = %v, want nil", err)
+	}
+	limit, err := NewLimit(sort, 1, 2)
+	if err != nil {
+		t.Fatalf("NewLimit() = %v, want nil", err)
+	}
+
+	relation, err := NewExplain(limit, false).Run(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	printer := NewPrinter()
+	limit.Print(printer)
+	want := strings.Split(strings.TrimSuffix(printer.String(), "\n"), "\n")
+	if len(relation.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(relation.Rows), len(want))
+	}
+	for i, row := range relation.Rows {
+		if row[0] != types.NewText(want[i]) {
+			t.Errorf("row %d = %v, want %q", i, row[0], want[i])
+		}
+	}
+}
+
+func TestExplainAnalyze(t *testing.T) {
+	db, schema := sortDatabase(t)
+	sort, err := NewSort(NewLoad("t", schema), []SortKey{{Expression: NewColumnReference(1, types.TypeText)}})
+	if err != nil {
+		t.Fatalf("NewSort() = %v, want nil", err)
+	}
+	limit, err := NewLimit(sort, 1, 2)
+	if err != nil {
+		t.Fatalf("NewLimit() = %v, want nil", err)
+	}
+
+	relation, err := NewExplain(limit, true).Run(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	elapsed := regexp.MustCompile(`time=\S+`)
+	got := make([]string, len(relation.Rows))
+	for i, row := range relation.Rows {
+		got[i] = elapsed.ReplaceAllString(row[0].(types.Text).Value(), "time=T")
+	}
+	want := []string{
+		"Limit {",
+		"   Actual: rows=2 loops=1 time=T memory=0B",
+		"   From:",
+		"   Sort {",
+		"      Actual: rows=3 loops=1 time=T memory=444B",
+		"      From:",
+		"      Load {",
+		"         Actual: rows=4 loops=1 time=T memory=0B",
+		"         Table: \"t\"",
+		"         Schema: TableSchema(t.a decimal, t.b text)",
+		"      }",
+		"      Keys:",
+		"         ColumnReference(1,text) asc nulls last",
+		"   }",
+		"   Offset: 1",
+		"   Count: 2",
+		"}",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	// the plan that was explained is left as it was
+	if _, ok := limit.From.(*Sort); !ok {
+		t.Errorf("limit.From = %T, want *Sort", limit.From)
+	}
+}
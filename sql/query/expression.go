@@ -8,9 +8,14 @@ import (
 // Expression represents an expression of column, constant and operation
 type Expression interface {
 	Type() types.Type
-	Evaluate(t *types.Row) types.Value
+	// Evaluate computes the value of the expression for a row, it fails on runtime errors such as a division by zero
+	Evaluate(t *types.Row) (types.Value, error)
 	Check(schema types.TableSchema) error
 	String() string
+	// Children returns the operands of the expression
+	Children() []Expression
+	// Attributes returns the properties of the expression other than its operands, see Attribute
+	Attributes() []Attribute
 }
 
 type Constant struct {
@@ -29,8 +34,16 @@ func (c Constant) String() string {
 	return fmt.Sprintf("%v", c.Value)
 }
 
-func (c Constant) Evaluate(t *types.Row) types.Value {
-	return c.Value
+func (c Constant) Children() []Expression {
+	return nil
+}
+
+func (c Constant) Attributes() []Attribute {
+	return []Attribute{{Name: "Value", Value: c.String()}, {Name: "Type", Value: c.Type().String()}}
+}
+
+func (c Constant) Evaluate(t *types.Row) (types.Value, error) {
+	return c.Value, nil
 }
 
 func (c Constant) Check(schema types.TableSchema) error {
@@ -54,8 +67,19 @@ func (c ColumnReference) String() string {
 	return fmt.Sprintf("ColumnReference(%d,%s)", c.Index, c.T)
 }
 
-func (c ColumnReference) Evaluate(t *types.Row) types.Value {
-	return t.Values[c.Index]
+func (c ColumnReference) Children() []Expression {
+	return nil
+}
+
+func (c ColumnReference) Attributes() []Attribute {
+	return []Attribute{{Name: "Index", Value: c.Index}, {Name: "Type", Value: c.T.String()}}
+}
+
+func (c ColumnReference) Evaluate(t *types.Row) (types.Value, error) {
+	if c.Index < 0 || c.Index >= len(t.Values) {
+		return nil, fmt.Errorf("%s: column index out of range for a row of %d values", c, len(t.Values))
+	}
+	return t.Values[c.Index], nil
 }
 
 func (c ColumnReference) Check(schema types.TableSchema) error {
@@ -88,21 +112,31 @@ func (b *BinaryOperation) Type() types.Type {
 	return types.TypeBoolean
 }
 
-func (b *BinaryOperation) Evaluate(t *types.Row) types.Value {
-	left := b.Left.Evaluate(t)
-	right := b.Right.Evaluate(t)
+func (b *BinaryOperation) Evaluate(t *types.Row) (types.Value, error) {
+	left, err := b.Left.Evaluate(t)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.Right.Evaluate(t)
+	if err != nil {
+		return nil, err
+	}
 
 	var result bool
 
 	switch left.Compare(right) {
+	case types.ComparisonUnknown:
+		return types.NewNull(types.TypeBoolean), nil
+	case types.ComparisonIncomparable:
+		return nil, fmt.Errorf("%s: cannot compare %v and %v with operator %s", b, left, right, b.Operator)
 	case types.ComparisonEqual:
 		result = b.Operator == BinaryOperatorLe || b.Operator == BinaryOperatorGe || b.Operator == BinaryOperatorEq
 	case types.ComparisonLess:
-		result = b.Operator == BinaryOperatorLt || b.Operator == BinaryOperatorLe
+		result = b.Operator == BinaryOperatorLt || b.Operator == BinaryOperatorLe || b.Operator == BinaryOperatorNe
 	case types.ComparisonGreater:
-		result = b.Operator == BinaryOperatorGt || b.Operator == BinaryOperatorGe
+		result = b.Operator == BinaryOperatorGt || b.Operator == BinaryOperatorGe || b.Operator == BinaryOperatorNe
 	}
-	return types.NewBoolean(result)
+	return types.NewBoolean(result), nil
 }
 
 func (b *BinaryOperation) Check(schema types.TableSchema) error {
@@ -119,6 +153,55 @@ func (b *BinaryOperation) String() string {
 	return fmt.Sprintf("BinaryOperation(%s %s %s)", b.Left, b.Operator, b.Right)
 }
 
+func (b *BinaryOperation) Children() []Expression {
+	return []Expression{b.Left, b.Right}
+}
+
+func (b *BinaryOperation) Attributes() []Attribute {
+	return []Attribute{{Name: "Operator", Value: b.Operator.String()}}
+}
+
+// IsNull tests whether an expression is NULL, the result is never NULL itself
+type IsNull struct {
+	Expression Expression
+	Negated    bool
+}
+
+func NewIsNull(expression Expression, negated bool) *IsNull {
+	return &IsNull{Expression: expression, Negated: negated}
+}
+
+func (n *IsNull) Type() types.Type {
+	return types.TypeBoolean
+}
+
+func (n *IsNull) Evaluate(t *types.Row) (types.Value, error) {
+	value, err := n.Expression.Evaluate(t)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewBoolean(types.IsNull(value) != n.Negated), nil
+}
+
+func (n *IsNull) Check(schema types.TableSchema) error {
+	return n.Expression.Check(schema)
+}
+
+func (n *IsNull) String() string {
+	if n.Negated {
+		return fmt.Sprintf("IsNotNull(%s)", n.Expression)
+	}
+	return fmt.Sprintf("IsNull(%s)", n.Expression)
+}
+
+func (n *IsNull) Children() []Expression {
+	return []Expression{n.Expression}
+}
+
+func (n *IsNull) Attributes() []Attribute {
+	return []Attribute{{Name: "Negated", Value: n.Negated}}
+}
+
 type BinaryOperator int
 
 const (
@@ -0,0 +1,325 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// HashJoin joins the rows whose keys are equal, LeftKeys are evaluated against the left input and RightKeys
+// against the right input. Condition, when not nil, must also hold for a pair of rows to match, it is evaluated
+// against the combined schema. The rows of the build side are put in a hash table that the other side probes.
+type HashJoin struct {
+	Type      JoinType
+	Left      QueryPlan
+	Right     QueryPlan
+	LeftKeys  []Expression
+	RightKeys []Expression
+	Condition Expression
+	// BuildLeft builds the hash table from the left input rather than the right one, it should be the smaller input
+	BuildLeft      bool
+	combinedSchema types.TableSchema
+}
+
+func NewHashJoin(t JoinType, left, right QueryPlan, leftKeys, rightKeys []Expression, condition Expression, buildLeft bool) (*HashJoin, error) {
+	if t < JoinTypeInner || t > JoinTypeFullOuter {
+		return nil, fmt.Errorf("unsupported join type %v", t)
+	}
+	if err := checkJoinKeys(left, right, leftKeys, rightKeys); err != nil {
+		return nil, err
+	}
+	if err := checkResidualCondition(left, right, condition); err != nil {
+		return nil, err
+	}
+	return &HashJoin{
+		Type:           t,
+		Left:           left,
+		Right:          right,
+		LeftKeys:       leftKeys,
+		RightKeys:      rightKeys,
+		Condition:      condition,
+		BuildLeft:      buildLeft,
+		combinedSchema: CombineSchemas(nullableIf(left.Schema(), t.preservesRight()), nullableIf(right.Schema(), t.preservesLeft())),
+	}, nil
+}
+
+func (j *HashJoin) Schema() types.TableSchema {
+	return j.combinedSchema
+}
+
+// Open reads the whole build side into a hash table, the probe side is streamed
+func (j *HashJoin) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	build, probe := j.Right, j.Left
+	buildKeys, probeKeys := j.RightKeys, j.LeftKeys
+	preserveBuild, preserveProbe := j.Type.preservesRight(), j.Type.preservesLeft()
+	if j.BuildLeft {
+		build, probe = probe, build
+		buildKeys, probeKeys = probeKeys, buildKeys
+		preserveBuild, preserveProbe = preserveProbe, preserveBuild
+	}
+
+	iterator, err := build.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := drain(iterator)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]types.Value, len(rows))
+	buckets := make(map[uint64][]int)
+	for i, values := range rows {
+		keys[i], err = evaluateJoinKeys(buildKeys, &types.Row{Schema: build.Schema(), Values: values})
+		if err != nil {
+			return nil, err
+		}
+		// NULL never equals anything, so rows with a NULL key can only be returned unmatched
+		if keys[i] != nil {
+			h := hashKeys(keys[i])
+			buckets[h] = append(buckets[h], i)
+		}
+	}
+	probeRows, err := probe.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hashJoinIterator{
+		join:          j,
+		schema:        j.Schema(),
+		build:         rows,
+		buildKeys:     keys,
+		buckets:       buckets,
+		buildMatched:  make([]bool, len(rows)),
+		buildNulls:    nullRow(build.Schema()),
+		preserveBuild: preserveBuild,
+		probe:         probeRows,
+		probeSchema:   probe.Schema(),
+		probeKeys:     probeKeys,
+		probeNulls:    nullRow(probe.Schema()),
+		preserveProbe: preserveProbe,
+	}, nil
+}
+
+func (j *HashJoin) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, j, db)
+}
+
+type hashJoinIterator struct {
+	join   *HashJoin
+	schema types.TableSchema
+
+	build         [][]types.Value
+	buildKeys     [][]types.Value
+	buckets       map[uint64][]int
+	buildMatched  []bool
+	buildNulls    []types.Value
+	preserveBuild bool
+
+	probe         RowIterator
+	probeSchema   types.TableSchema
+	probeKeys     []Expression
+	probeNulls    []types.Value
+	preserveProbe bool
+
+	// probeRow is the probe row being joined with the build rows whose keys have the same hash
+	probeRow       []types.Value
+	probeRowKeys   []types.Value
+	probeMatched   bool
+	candidates     []int
+	probeDone      bool
+	unmatchedBuild int
+	size           int
+}
+
+func (j *hashJoinIterator) Next() ([]types.Value, error) {
+	for !j.probeDone {
+		if j.probeRow == nil {
+			row, err := j.probe.Next()
+			if err != nil {
+				return nil, err
+			}
+			j.probeRow = row
+			if j.probeRow == nil {
+				j.probeDone = true
+				break
+			}
+			j.probeMatched = false
+			j.candidates = nil
+			j.probeRowKeys, err = evaluateJoinKeys(j.probeKeys, &types.Row{Schema: j.probeSchema, Values: j.probeRow})
+			if err != nil {
+				return nil, err
+			}
+			if j.probeRowKeys != nil {
+				j.candidates = j.buckets[hashKeys(j.probeRowKeys)]
+			}
+		}
+		for len(j.candidates) > 0 {
+			i := j.candidates[0]
+			j.candidates = j.candidates[1:]
+			if !keysEqual(j.buildKeys[i], j.probeRowKeys) {
+				continue
+			}
+			values := j.combine(j.build[i], j.probeRow)
+			if j.join.Condition != nil {
+				ok, err := isTrue(j.join.Condition, &types.Row{Schema: j.schema, Values: values})
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+			}
+			j.probeMatched = true
+			j.buildMatched[i] = true
+			return values, nil
+		}
+		probeRow := j.probeRow
+		j.probeRow = nil
+		if !j.probeMatched && j.preserveProbe {
+			return j.combine(j.buildNulls, probeRow), nil
+		}
+	}
+
+	if j.preserveBuild {
+		for j.unmatchedBuild < len(j.build) {
+			i := j.unmatchedBuild
+			j.unmatchedBuild++
+			if !j.buildMatched[i] {
+				return j.combine(j.build[i], j.probeNulls), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (j *hashJoinIterator) memory() int {
+	if j.size == 0 {
+		j.size = rowsMemory(j.build)
+	}
+	return j.size
+}
+
+// combine puts a build row and a probe row back in the order of the join inputs
+func (j *hashJoinIterator) combine(build, probe []types.Value) []types.Value {
+	if j.join.BuildLeft {
+		return combinedRows(build, probe)
+	}
+	return combinedRows(probe, build)
+}
+
+func (j *hashJoinIterator) Close() {
+	j.probe.Close()
+	j.build, j.buildKeys, j.buckets, j.buildMatched, j.probeRow = nil, nil, nil, nil, nil
+}
+
+func (j *HashJoin) Print(printer *Printer) {
+	printer.Println("HashJoin {")
+	printer.Indent()
+	printer.Println("Type: %s", j.Type)
+	build := "right"
+	if j.BuildLeft {
+		build = "left"
+	}
+	printer.Println("Build: %s", build)
+	printer.Println("Left:")
+	j.Left.Print(printer)
+	printer.Println("Right:")
+	j.Right.Print(printer)
+	printer.Println("Keys:")
+	printer.Indent()
+	for i := range j.LeftKeys {
+		printer.Println("%s = %s", j.LeftKeys[i], j.RightKeys[i])
+	}
+	printer.Dedent()
+	if j.Condition != nil {
+		printer.Println("Condition: %s", j.Condition)
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (j *HashJoin) Children() []QueryPlan {
+	return []QueryPlan{j.Left, j.Right}
+}
+
+func (j *HashJoin) Attributes() []Attribute {
+	build := "right"
+	if j.BuildLeft {
+		build = "left"
+	}
+	attributes := []Attribute{
+		{Name: "Type", Value: j.Type.String()},
+		{Name: "Build", Value: build},
+		{Name: "LeftKeys", Value: j.LeftKeys},
+		{Name: "RightKeys", Value: j.RightKeys},
+	}
+	if j.Condition != nil {
+		attributes = append(attributes, Attribute{Name: "Condition", Value: j.Condition})
+	}
+	return attributes
+}
+
+// checkJoinKeys checks that LeftKeys[i] and RightKeys[i] can be compared for equality
+func checkJoinKeys(left, right QueryPlan, leftKeys, rightKeys []Expression) error {
+	if len(leftKeys) == 0 || len(leftKeys) != len(rightKeys) {
+		return fmt.Errorf("join requires the same number of keys on both sides, got %d and %d", len(leftKeys), len(rightKeys))
+	}
+	for i := range leftKeys {
+		if err := leftKeys[i].Check(left.Schema()); err != nil {
+			return err
+		}
+		if err := rightKeys[i].Check(right.Schema()); err != nil {
+			return err
+		}
+		if leftKeys[i].Type() != rightKeys[i].Type() {
+			return fmt.Errorf("mismatched types: %v and %v", leftKeys[i].Type(), rightKeys[i].Type())
+		}
+		if !leftKeys[i].Type().Comparable() {
+			return fmt.Errorf("cannot join on %s: values of type %v are incomparable", leftKeys[i], leftKeys[i].Type())
+		}
+	}
+	return nil
+}
+
+// checkResidualCondition checks the part of a join condition that is not a key equality, which may be nil
+func checkResidualCondition(left, right QueryPlan, condition Expression) error {
+	if condition == nil {
+		return nil
+	}
+	if condition.Type() != types.TypeBoolean {
+		return fmt.Errorf("condition must be a boolean expression %v", condition)
+	}
+	return condition.Check(CombineSchemas(left.Schema(), right.Schema()))
+}
+
+// evaluateJoinKeys returns the keys of a row, or nil when any of them is NULL
+func evaluateJoinKeys(keys []Expression, row *types.Row) ([]types.Value, error) {
+	values := make([]types.Value, len(keys))
+	for i, key := range keys {
+		value, err := key.Evaluate(row)
+		if err != nil || types.IsNull(value) {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func hashKeys(keys []types.Value) uint64 {
+	var h uint64
+	for _, key := range keys {
+		h = h*31 + key.Hash()
+	}
+	return h
+}
+
+func keysEqual(a, b []types.Value) bool {
+	for i := range a {
+		if a[i].Compare(b[i]) != types.ComparisonEqual {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func hashJoinDatabase(t *testing.T) (*storage.Database, types.TableSchema) {
+	t.Helper()
+	schema := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "id", Type: types.TypeDecimal, Nullable: true},
+			{Name: "name", Type: types.TypeText},
+		},
+	}
+	d := types.NewDecimal
+	null := types.NewNull(types.TypeDecimal)
+	db := storage.NewDatabase()
+	for name, rows := range map[string][][]types.Value{
+		"l": {{d("1"), types.NewText("a")}, {d("2"), types.NewText("b")}, {d("2"), types.NewText("c")}, {null, types.NewText("d")}, {d("5"), types.NewText("e")}},
+		"r": {{d("1.0"), types.NewText("a")}, {d("2"), types.NewText("x")}, {d("2.00"), types.NewText("b")}, {null, types.NewText("d")}, {d("7"), types.NewText("y")}},
+	} {
+		table, err := db.CreateTable(name, schema)
+		if err != nil {
+			t.Fatalf("db.CreateTable() = %v, want nil", err)
+		}
+		for _, row := range rows {
+			if err := table.Insert(row); err != nil {
+				t.Fatalf("table.Insert() = %v, want nil", err)
+			}
+		}
+	}
+	return db, schema
+}
+
+// sortedRows orders rows by their string representation, as the hash join does not keep the order of the nested loop
+func sortedRows(rows [][]types.Value) []string {
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i] = fmt.Sprint(row)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestHashJoin(t *testing.T) {
+	db, schema := hashJoinDatabase(t)
+	eq := func(left, right Expression) Expression {
+		e, err := NewBinaryOperation(left, right, BinaryOperatorEq)
+		if err != nil {
+			t.Fatalf("unexpected error in NewBinaryOperation: %v", err)
+		}
+		return e
+	}
+	leftID, rightID := NewColumnReference(0, types.TypeDecimal), NewColumnReference(2, types.TypeDecimal)
+	leftName, rightName := NewColumnReference(1, types.TypeText), NewColumnReference(3, types.TypeText)
+
+	cases := []struct {
+		name      string
+		condition Expression
+		residual  Expression
+	}{
+		{name: "single key", condition: eq(leftID, rightID)},
+		{name: "residual condition", condition: &And{Left: eq(leftID, rightID), Right: eq(leftName, rightName)}, residual: eq(leftName, rightName)},
+	}
+
+	for _, c := range cases {
+		for _, joinType := range []JoinType{JoinTypeInner, JoinTypeLeftOuter, JoinTypeRightOuter, JoinTypeFullOuter} {
+			for _, buildLeft := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s %s build left %v", c.name, joinType, buildLeft), func(t *testing.T) {
+					loop, err := NewJoin(joinType, NewLoad("l", schema), NewLoad("r", schema), c.condition)
+					if err != nil {
+						t.Fatalf("unexpected error in NewJoin: %v", err)
+					}
+					hash, err := NewHashJoin(joinType, NewLoad("l", schema), NewLoad("r", schema),
+						[]Expression{leftID}, []Expression{NewColumnReference(0, types.TypeDecimal)}, c.residual, buildLeft)
+					if err != nil {
+						t.Fatalf("unexpected error in NewHashJoin: %v", err)
+					}
+					if !reflect.DeepEqual(hash.Schema(), loop.Schema()) {
+						t.Errorf("hash.Schema() = %v, want %v", hash.Schema(), loop.Schema())
+					}
+					got, want := sortedRows(run(t, hash, db).Rows), sortedRows(run(t, loop, db).Rows)
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("hash.Run() = %v, want %v", got, want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestNewHashJoinInvalid(t *testing.T) {
+	_, schema := hashJoinDatabase(t)
+	left, right := NewLoad("l", schema), NewLoad("r", schema)
+	id, name := NewColumnReference(0, types.TypeDecimal), NewColumnReference(1, types.TypeText)
+
+	cases := []struct {
+		name      string
+		leftKeys  []Expression
+		rightKeys []Expression
+		condition Expression
+	}{
+		{name: "no keys"},
+		{name: "unbalanced keys", leftKeys: []Expression{id}, rightKeys: []Expression{id, name}},
+		{name: "mismatched types", leftKeys: []Expression{id}, rightKeys: []Expression{name}},
+		{name: "key out of range", leftKeys: []Expression{id}, rightKeys: []Expression{NewColumnReference(2, types.TypeDecimal)}},
+		{name: "non boolean condition", leftKeys: []Expression{id}, rightKeys: []Expression{id}, condition: name},
+	}
+
+	for _, c := range cases {
+		if _, err := NewHashJoin(JoinTypeInner, left, right, c.leftKeys, c.rightKeys, c.condition, false); err == nil {
+			t.Errorf("NewHashJoin() with %s returned nil, want error", c.name)
+		}
+	}
+}
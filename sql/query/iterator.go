@@ -0,0 +1,137 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// RowIterator streams the rows of an opened QueryPlan. Rows are pulled one at a time by the consumer,
+// which must call Close once it is done, even if it stops before the last row, to release the
+// resources held by the iterator and the iterators of its inputs.
+type RowIterator interface {
+	// Next returns the next row, or nil once every row has been returned. The returned slice is
+	// shared with the input and must not be modified. Once Next fails the iterator must only be closed.
+	Next() ([]types.Value, error)
+	Close()
+}
+
+// ErrQueryCanceled is returned when the context of a query is canceled or its deadline is exceeded,
+// the error also wraps the error of the context
+var ErrQueryCanceled = errors.New("query canceled")
+
+// checkCanceled is called by the operators at row boundaries, so that a canceled query stops promptly
+func checkCanceled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrQueryCanceled, err)
+	}
+	return nil
+}
+
+// Materialize runs a plan to completion and collects its rows into a relation
+func Materialize(ctx context.Context, plan QueryPlan, db *storage.Database) (*types.Relation, error) {
+	iterator, err := plan.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := drain(iterator)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = [][]types.Value{}
+	}
+	return &types.Relation{
+		Schema: resultSchema(plan, db),
+		Rows:   rows,
+	}, nil
+}
+
+// resultSchema is the schema of the relation returned by Run. A table that is only filtered, sorted or
+// limited keeps the column names it is stored with, as the prefix is only needed to tell tables apart.
+func resultSchema(plan QueryPlan, db *storage.Database) types.TableSchema {
+	switch p := plan.(type) {
+	case *Load:
+		if table, err := db.GetTable(p.TableName); err == nil {
+			return table.Schema
+		}
+	case *Select:
+		return resultSchema(p.From, db)
+	case *Sort:
+		return resultSchema(p.From, db)
+	case *Limit:
+		return resultSchema(p.From, db)
+	case *TopN:
+		return resultSchema(p.From, db)
+	case *Empty:
+		return resultSchema(p.From, db)
+	}
+	return plan.Schema()
+}
+
+// drain reads every remaining row of an iterator and closes it, for operators that need their whole input
+func drain(iterator RowIterator) ([][]types.Value, error) {
+	defer iterator.Close()
+	var rows [][]types.Value
+	for {
+		row, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			return rows, nil
+		}
+		rows = append(rows, row)
+	}
+}
+
+// openAll opens the inputs of an operator, closing those already opened when one of them fails
+func openAll(ctx context.Context, db *storage.Database, plans ...QueryPlan) ([]RowIterator, error) {
+	iterators := make([]RowIterator, 0, len(plans))
+	for _, plan := range plans {
+		iterator, err := plan.Open(ctx, db)
+		if err != nil {
+			for _, opened := range iterators {
+				opened.Close()
+			}
+			return nil, err
+		}
+		iterators = append(iterators, iterator)
+	}
+	return iterators, nil
+}
+
+// sliceIterator returns rows that are already in memory. Buffered is set by the operators that
+// collected the rows themselves, rather than reading those of a table.
+type sliceIterator struct {
+	ctx      context.Context
+	rows     [][]types.Value
+	buffered bool
+	size     int
+}
+
+func (s *sliceIterator) Next() ([]types.Value, error) {
+	if err := checkCanceled(s.ctx); err != nil {
+		return nil, err
+	}
+	if len(s.rows) == 0 {
+		return nil, nil
+	}
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
+
+func (s *sliceIterator) Close() {
+	s.rows = nil
+}
+
+// memory is the size of the rows when the iterator was first measured, before any of them was returned
+func (s *sliceIterator) memory() int {
+	if s.buffered && s.size == 0 {
+		s.size = rowsMemory(s.rows)
+	}
+	return s.size
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"testing"
+)
+
+// countingPlan records how many rows are pulled from the plan it wraps and whether it was closed
+type countingPlan struct {
+	QueryPlan
+	pulled int
+	closed bool
+}
+
+func (c *countingPlan) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	from, err := c.QueryPlan.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return &countingIterator{from: from, plan: c}, nil
+}
+
+func (c *countingPlan) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, c, db)
+}
+
+type countingIterator struct {
+	from RowIterator
+	plan *countingPlan
+}
+
+func (c *countingIterator) Next() ([]types.Value, error) {
+	row, err := c.from.Next()
+	if row != nil {
+		c.plan.pulled++
+	}
+	return row, err
+}
+
+func (c *countingIterator) Close() {
+	c.from.Close()
+	c.plan.closed = true
+}
+
+func TestLimitStopsEarly(t *testing.T) {
+	db, schema := sortDatabase(t)
+	counting := &countingPlan{QueryPlan: NewLoad("t", schema)}
+	limit, err := NewLimit(counting, 1, 2)
+	if err != nil {
+		t.Fatalf("NewLimit() = %v, want nil", err)
+	}
+
+	iterator, err := limit.Open(context.Background(), db)
+	if err != nil {
+		t.Fatalf("limit.Open() = %v, want nil", err)
+	}
+	var got []string
+	for {
+		row, err := iterator.Next()
+		if err != nil {
+			t.Fatalf("iterator.Next() = %v, want nil", err)
+		}
+		if row == nil {
+			break
+		}
+		got = append(got, row[1].(types.Text).Value())
+	}
+	if len(got) != 2 || got[0] != "y" || got[1] != "z" {
+		t.Errorf("rows = %v, want [y z]", got)
+	}
+	if counting.pulled != 3 {
+		t.Errorf("pulled %d rows, want 3", counting.pulled)
+	}
+	if !counting.closed {
+		t.Errorf("input was not closed once the limit was reached")
+	}
+	iterator.Close()
+}
+
+func TestJoinStreamsLeftInput(t *testing.T) {
+	db, schema := sortDatabase(t)
+	left := &countingPlan{QueryPlan: NewLoad("t", schema)}
+	right := &countingPlan{QueryPlan: NewAliasedLoad("t", "u", schema)}
+	join, err := NewJoin(JoinTypeInner, left, right, NewConstant(types.NewBoolean(true)))
+	if err != nil {
+		t.Fatalf("NewJoin() = %v, want nil", err)
+	}
+
+	iterator, err := join.Open(context.Background(), db)
+	if err != nil {
+		t.Fatalf("join.Open() = %v, want nil", err)
+	}
+	if row, err := iterator.Next(); row == nil {
+		t.Fatalf("Next() = nil, %v, want a row", err)
+	}
+	if left.pulled != 1 {
+		t.Errorf("pulled %d left rows, want 1", left.pulled)
+	}
+	if right.pulled != 4 || !right.closed {
+		t.Errorf("pulled %d right rows, closed %t, want the right input buffered and closed", right.pulled, right.closed)
+	}
+	iterator.Close()
+	if !left.closed {
+		t.Errorf("left input was not closed")
+	}
+}
+
+func TestJoinCanceled(t *testing.T) {
+	db, schema := sortDatabase(t)
+	// no pair of rows matches, so the join goes through every pair without returning
+	join, err := NewJoin(JoinTypeInner, NewLoad("t", schema), NewAliasedLoad("t", "u", schema), NewConstant(types.NewBoolean(false)))
+	if err != nil {
+		t.Fatalf("NewJoin() = %v, want nil", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	iterator, err := join.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("join.Open() = %v, want nil", err)
+	}
+	defer iterator.Close()
+	cancel()
+	if row, err := iterator.Next(); !errors.Is(err, ErrQueryCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Next() = %v, %v, want ErrQueryCanceled", row, err)
+	}
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"github.com/Vignesh-Rajarajan/go-db/storage"
 	"github.com/Vignesh-Rajarajan/go-db/types"
@@ -12,6 +13,7 @@ const (
 	JoinTypeInner JoinType = iota
 	JoinTypeLeftOuter
 	JoinTypeRightOuter
+	JoinTypeFullOuter
 )
 
 func (j JoinType) String() string {
@@ -22,6 +24,8 @@ func (j JoinType) String() string {
 		return "left outer"
 	case JoinTypeRightOuter:
 		return "right outer"
+	case JoinTypeFullOuter:
+		return "full outer"
 	}
 	return fmt.Sprintf("Unknown JoinType(%d)", j)
 }
@@ -35,8 +39,8 @@ type Join struct {
 }
 
 func NewJoin(t JoinType, left QueryPlan, right QueryPlan, condition Expression) (*Join, error) {
-	if t != JoinTypeInner {
-		return nil, fmt.Errorf("only inner join is supported")
+	if t < JoinTypeInner || t > JoinTypeFullOuter {
+		return nil, fmt.Errorf("unsupported join type %v", t)
 	}
 	if condition.Type() != types.TypeBoolean {
 		return nil, fmt.Errorf("condition must be a boolean expression %v", condition)
@@ -45,38 +49,134 @@ func NewJoin(t JoinType, left QueryPlan, right QueryPlan, condition Expression)
 	if err := condition.Check(combinedSchema); err != nil {
 		return nil, err
 	}
-	return &Join{Type: t, Left: left, Right: right, Condition: condition, combinedSchema: combinedSchema}, nil
+	j := &Join{Type: t, Left: left, Right: right, Condition: condition}
+	j.combinedSchema = CombineSchemas(nullableIf(left.Schema(), j.Type.preservesRight()), nullableIf(right.Schema(), j.Type.preservesLeft()))
+	return j, nil
 }
 
 func (j *Join) Schema() types.TableSchema {
 	return j.combinedSchema
 }
 
-func (j *Join) Run(db *storage.Database) *types.Relation {
-	left := j.Left.Run(db)
-	right := j.Right.Run(db)
-	schema := j.Schema()
+// Open streams the left input and buffers the right input, which is scanned once for every left row
+func (j *Join) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	iterator, err := j.Right.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	right, err := drain(iterator)
+	if err != nil {
+		return nil, err
+	}
+	left, err := j.Left.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return &joinIterator{
+		ctx:          ctx,
+		join:         j,
+		schema:       j.Schema(),
+		left:         left,
+		right:        right,
+		rightMatched: make([]bool, len(right)),
+	}, nil
+}
 
-	var rows [][]types.Value
+func (j *Join) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, j, db)
+}
 
-	for _, leftRow := range left.Rows {
-		for _, rightRow := range right.Rows {
-			row := &types.Row{
-				Schema: schema,
-				Values: combinedRows(leftRow, rightRow),
-			}
+type joinIterator struct {
+	ctx    context.Context
+	join   *Join
+	schema types.TableSchema
+	left   RowIterator
+	right  [][]types.Value
+	// rightMatched records the right rows that matched any left row, for right and full outer joins
+	rightMatched []bool
+
+	// leftRow is the left row being joined, it is matched against the right rows from position on
+	leftRow     []types.Value
+	leftMatched bool
+	position    int
+	leftDone    bool
+	// unmatched is the next right row to check once every left row has been joined
+	unmatched int
+	size      int
+}
 
-			got := j.Condition.Evaluate(row).(types.Boolean)
-			if got.Bool() {
-				rows = append(rows, row.Values)
+func (j *joinIterator) Next() ([]types.Value, error) {
+	for !j.leftDone {
+		if j.leftRow == nil {
+			row, err := j.left.Next()
+			if err != nil {
+				return nil, err
 			}
+			j.leftRow = row
+			if j.leftRow == nil {
+				j.leftDone = true
+				break
+			}
+			j.leftMatched = false
+			j.position = 0
+		}
+		for j.position < len(j.right) {
+			// every pair of rows is a row boundary, a cross join may go through many of them without a match
+			if err := checkCanceled(j.ctx); err != nil {
+				return nil, err
+			}
+			i := j.position
+			j.position++
+			values := combinedRows(j.leftRow, j.right[i])
+			ok, err := isTrue(j.join.Condition, &types.Row{Schema: j.schema, Values: values})
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				j.leftMatched = true
+				j.rightMatched[i] = true
+				return values, nil
+			}
+		}
+		leftRow := j.leftRow
+		j.leftRow = nil
+		if !j.leftMatched && j.join.Type.preservesLeft() {
+			return combinedRows(leftRow, nullRow(j.join.Right.Schema())), nil
+		}
+	}
 
+	if j.join.Type.preservesRight() {
+		for j.unmatched < len(j.right) {
+			i := j.unmatched
+			j.unmatched++
+			if !j.rightMatched[i] {
+				return combinedRows(nullRow(j.join.Left.Schema()), j.right[i]), nil
+			}
 		}
 	}
-	return &types.Relation{
-		Schema: schema,
-		Rows:   rows,
+	return nil, nil
+}
+
+func (j *joinIterator) Close() {
+	j.left.Close()
+	j.right, j.rightMatched, j.leftRow = nil, nil, nil
+}
+
+func (j *joinIterator) memory() int {
+	if j.size == 0 {
+		j.size = rowsMemory(j.right)
 	}
+	return j.size
+}
+
+// preservesLeft reports whether unmatched rows of the left input are kept, padded with NULLs
+func (j JoinType) preservesLeft() bool {
+	return j == JoinTypeLeftOuter || j == JoinTypeFullOuter
+}
+
+// preservesRight reports whether unmatched rows of the right input are kept, padded with NULLs
+func (j JoinType) preservesRight() bool {
+	return j == JoinTypeRightOuter || j == JoinTypeFullOuter
 }
 
 func (j *Join) Print(printer *Printer) {
@@ -93,6 +193,14 @@ func (j *Join) Print(printer *Printer) {
 
 }
 
+func (j *Join) Children() []QueryPlan {
+	return []QueryPlan{j.Left, j.Right}
+}
+
+func (j *Join) Attributes() []Attribute {
+	return []Attribute{{Name: "Type", Value: j.Type.String()}, {Name: "Condition", Value: j.Condition}}
+}
+
 func CombineSchemas(left, right types.TableSchema) types.TableSchema {
 	var columns []types.ColumnSchema
 	columns = append(columns, left.Columns...)
@@ -100,9 +208,31 @@ func CombineSchemas(left, right types.TableSchema) types.TableSchema {
 	return types.TableSchema{Columns: columns}
 }
 
+// nullableIf marks every column of the schema as nullable when the side is padded with NULLs by an outer join
+func nullableIf(schema types.TableSchema, padded bool) types.TableSchema {
+	if !padded {
+		return schema
+	}
+	columns := make([]types.ColumnSchema, len(schema.Columns))
+	for i, column := range schema.Columns {
+		column.Nullable = true
+		columns[i] = column
+	}
+	return types.TableSchema{Columns: columns}
+}
+
+// combinedRows is never nil, even for inputs without columns, as a nil row ends an iterator
 func combinedRows(left, right []types.Value) []types.Value {
-	var row []types.Value
+	row := make([]types.Value, 0, len(left)+len(right))
 	row = append(row, left...)
 	row = append(row, right...)
 	return row
 }
+
+func nullRow(schema types.TableSchema) []types.Value {
+	row := make([]types.Value, len(schema.Columns))
+	for i, column := range schema.Columns {
+		row[i] = types.NewNull(column.Type)
+	}
+	return row
+}
@@ -69,8 +69,75 @@ func TestNewJoin(t *testing.T) {
 		t.Errorf("join.Schema() = %v, want %v", gotSchema, wantSchema)
 	}
 
-	got := join.Run(sampleData.Database)
+	got := run(t, join, sampleData.Database)
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("join.Run() = %v, want %v", got, want)
 	}
 }
+
+func TestOuterJoin(t *testing.T) {
+	schema := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "id", Type: types.TypeDecimal},
+		},
+	}
+	db := storage.NewDatabase()
+	for name, ids := range map[string][]string{"l": {"1", "2", "3"}, "r": {"2", "3", "4"}} {
+		table, err := db.CreateTable(name, schema)
+		if err != nil {
+			t.Fatalf("db.CreateTable() = %v, want nil", err)
+		}
+		for _, id := range ids {
+			if err := table.Insert([]types.Value{types.NewDecimal(id)}); err != nil {
+				t.Fatalf("table.Insert() = %v, want nil", err)
+			}
+		}
+	}
+
+	d := types.NewDecimal
+	null := types.NewNull(types.TypeDecimal)
+	matched := [][]types.Value{{d("2"), d("2")}, {d("3"), d("3")}}
+
+	cases := []struct {
+		joinType JoinType
+		want     [][]types.Value
+	}{
+		{
+			joinType: JoinTypeInner,
+			want:     matched,
+		},
+		{
+			joinType: JoinTypeLeftOuter,
+			want:     [][]types.Value{{d("1"), null}, matched[0], matched[1]},
+		},
+		{
+			joinType: JoinTypeRightOuter,
+			want:     [][]types.Value{matched[0], matched[1], {null, d("4")}},
+		},
+		{
+			joinType: JoinTypeFullOuter,
+			want:     [][]types.Value{{d("1"), null}, matched[0], matched[1], {null, d("4")}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.joinType.String(), func(t *testing.T) {
+			condition, err := NewBinaryOperation(
+				NewColumnReference(0, types.TypeDecimal),
+				NewColumnReference(1, types.TypeDecimal),
+				BinaryOperatorEq,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error in NewBinaryOperation: %v", err)
+			}
+			join, err := NewJoin(c.joinType, NewLoad("l", schema), NewLoad("r", schema), condition)
+			if err != nil {
+				t.Fatalf("unexpected error in NewJoin: %v", err)
+			}
+			got := run(t, join, db)
+			if !reflect.DeepEqual(got.Rows, c.want) {
+				t.Errorf("join.Run() = %v, want %v", got.Rows, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package query
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"sort"
+)
+
+// Limit skips the first Offset rows and returns at most Count of the rest, a negative Count means no limit
+type Limit struct {
+	From   QueryPlan
+	Offset int
+	Count  int
+}
+
+func NewLimit(from QueryPlan, offset, count int) (*Limit, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("OFFSET must not be negative: %d", offset)
+	}
+	return &Limit{From: from, Offset: offset, Count: count}, nil
+}
+
+func (l *Limit) Schema() types.TableSchema {
+	return l.From.Schema()
+}
+
+func (l *Limit) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	from, err := l.From.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return &limitIterator{from: from, skip: l.Offset, remaining: l.Count}, nil
+}
+
+func (l *Limit) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, l, db)
+}
+
+// limitIterator stops reading its input, and closes it, as soon as Count rows have been returned
+type limitIterator struct {
+	from      RowIterator
+	skip      int
+	remaining int
+}
+
+func (l *limitIterator) Next() ([]types.Value, error) {
+	if l.remaining == 0 {
+		l.Close()
+		return nil, nil
+	}
+	for ; l.skip > 0; l.skip-- {
+		row, err := l.from.Next()
+		if err != nil || row == nil {
+			return nil, err
+		}
+	}
+	row, err := l.from.Next()
+	if err != nil {
+		return nil, err
+	}
+	if row != nil && l.remaining > 0 {
+		l.remaining--
+	}
+	return row, nil
+}
+
+func (l *limitIterator) Close() {
+	if l.from != nil {
+		l.from.Close()
+		l.from = nil
+	}
+}
+
+func (l *Limit) Print(printer *Printer) {
+	printer.Println("Limit {")
+	printer.Indent()
+	printer.Println("From:")
+	l.From.Print(printer)
+	printer.Println("Offset: %d", l.Offset)
+	printer.Println("Count: %d", l.Count)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (l *Limit) Children() []QueryPlan {
+	return []QueryPlan{l.From}
+}
+
+func (l *Limit) Attributes() []Attribute {
+	return []Attribute{{Name: "Offset", Value: l.Offset}, {Name: "Count", Value: l.Count}}
+}
+
+// TopN is a Sort followed by a Limit. It keeps only the first Offset+Count rows in a heap
+// instead of sorting the whole input.
+type TopN struct {
+	From   QueryPlan
+	Keys   []SortKey
+	Offset int
+	Count  int
+}
+
+func NewTopN(from QueryPlan, keys []SortKey, offset, count int) (*TopN, error) {
+	if _, err := NewSort(from, keys); err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("OFFSET must not be negative: %d", offset)
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("top-n requires a row count: %d", count)
+	}
+	return &TopN{From: from, Keys: keys, Offset: offset, Count: count}, nil
+}
+
+func (t *TopN) Schema() types.TableSchema {
+	return t.From.Schema()
+}
+
+// Open reads the whole input before returning the first row, keeping at most Offset+Count rows in memory
+func (t *TopN) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	schema := t.From.Schema()
+	from, err := t.From.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer from.Close()
+	keep := t.Offset + t.Count
+
+	h := &topNHeap{keys: t.Keys}
+	for i := 0; keep > 0; i++ {
+		values, err := from.Next()
+		if err != nil {
+			return nil, err
+		}
+		if values == nil {
+			break
+		}
+		keys, err := evaluateKeys(t.Keys, &types.Row{Schema: schema, Values: values})
+		if err != nil {
+			return nil, err
+		}
+		row := sortRow{values: values, keys: keys, sequence: i}
+		if h.Len() < keep {
+			heap.Push(h, row)
+		} else if h.less(row, h.rows[0]) {
+			h.rows[0] = row
+			heap.Fix(h, 0)
+		}
+	}
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	sort.Slice(h.rows, func(i, j int) bool {
+		return h.less(h.rows[i], h.rows[j])
+	})
+	if h.err != nil {
+		return nil, h.err
+	}
+	var rows [][]types.Value
+	for _, row := range h.rows[min(t.Offset, len(h.rows)):] {
+		rows = append(rows, row.values)
+	}
+	return &sliceIterator{ctx: ctx, rows: rows, buffered: true}, nil
+}
+
+func (t *TopN) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, t, db)
+}
+
+func (t *TopN) Print(printer *Printer) {
+	printer.Println("TopN {")
+	printer.Indent()
+	printer.Println("From:")
+	t.From.Print(printer)
+	printer.Println("Keys:")
+	printer.Indent()
+	for _, key := range t.Keys {
+		printer.Println("%s", key)
+	}
+	printer.Dedent()
+	printer.Println("Offset: %d", t.Offset)
+	printer.Println("Count: %d", t.Count)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (t *TopN) Children() []QueryPlan {
+	return []QueryPlan{t.From}
+}
+
+func (t *TopN) Attributes() []Attribute {
+	return []Attribute{
+		{Name: "Keys", Value: sortKeyAttributes(t.Keys)},
+		{Name: "Offset", Value: t.Offset},
+		{Name: "Count", Value: t.Count},
+	}
+}
+
+// topNHeap is a max-heap in sort order, its root is the row that would be dropped first
+type topNHeap struct {
+	keys []SortKey
+	rows []sortRow
+	// err is the first error met while comparing rows
+	err error
+}
+
+// less orders rows as a stable sort would, falling back to the input position on ties
+func (h *topNHeap) less(a, b sortRow) bool {
+	c, err := compareKeys(h.keys, a.keys, b.keys)
+	if err != nil && h.err == nil {
+		h.err = err
+	}
+	if c != 0 {
+		return c < 0
+	}
+	return a.sequence < b.sequence
+}
+
+func (h *topNHeap) Len() int           { return len(h.rows) }
+func (h *topNHeap) Less(i, j int) bool { return h.less(h.rows[j], h.rows[i]) }
+func (h *topNHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+func (h *topNHeap) Push(x any)         { h.rows = append(h.rows, x.(sortRow)) }
+func (h *topNHeap) Pop() any {
+	last := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return last
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func TestLimit(t *testing.T) {
+	db, schema := sortDatabase(t)
+
+	cases := []struct {
+		offset, count int
+		want          []string
+	}{
+		{offset: 0, count: 2, want: []string{"x", "y"}},
+		{offset: 1, count: 2, want: []string{"y", "z"}},
+		{offset: 3, count: 2, want: []string{"w"}},
+		{offset: 2, count: -1, want: []string{"z", "w"}},
+		{offset: 5, count: -1, want: nil},
+		{offset: 0, count: 0, want: nil},
+	}
+
+	for _, c := range cases {
+		l, err := NewLimit(NewLoad("t", schema), c.offset, c.count)
+		if err != nil {
+			t.Fatalf("NewLimit() = %v, want nil", err)
+		}
+		got := names(run(t, l, db))
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Limit(offset %d, count %d) = %v, want %v", c.offset, c.count, got, c.want)
+		}
+	}
+
+	if _, err := NewLimit(NewLoad("t", schema), -1, 1); err == nil {
+		t.Errorf("NewLimit() with negative offset returned nil, want error")
+	}
+}
+
+func TestTopN(t *testing.T) {
+	db, schema := sortDatabase(t)
+	a := NewColumnReference(0, types.TypeDecimal)
+	b := NewColumnReference(1, types.TypeText)
+
+	keyLists := [][]SortKey{
+		{{Expression: a}},
+		{{Expression: a, Descending: true, NullsFirst: true}},
+		{{Expression: a, Descending: true}, {Expression: b}},
+		{{Expression: b, Descending: true}},
+	}
+
+	// TopN must return exactly what a stable Sort followed by a Limit returns
+	for _, keys := range keyLists {
+		for offset := 0; offset <= 4; offset++ {
+			for count := 0; count <= 5; count++ {
+				sorted, err := NewSort(NewLoad("t", schema), keys)
+				if err != nil {
+					t.Fatalf("NewSort() = %v, want nil", err)
+				}
+				limit, err := NewLimit(sorted, offset, count)
+				if err != nil {
+					t.Fatalf("NewLimit() = %v, want nil", err)
+				}
+				topN, err := NewTopN(NewLoad("t", schema), keys, offset, count)
+				if err != nil {
+					t.Fatalf("NewTopN() = %v, want nil", err)
+				}
+
+				want, got := names(run(t, limit, db)), names(run(t, topN, db))
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("TopN(%v, offset %d, count %d) = %v, want %v", keys, offset, count, got, want)
+				}
+			}
+		}
+	}
+
+	if _, err := NewTopN(NewLoad("t", schema), keyLists[0], 0, -1); err == nil {
+		t.Errorf("NewTopN() without count returned nil, want error")
+	}
+}
+
+func names(r *types.Relation) []string {
+	var result []string
+	for _, row := range r.Rows {
+		result = append(result, row[1].(types.Text).Value())
+	}
+	return result
+}
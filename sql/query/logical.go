@@ -0,0 +1,193 @@
+package query
+
+import (
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// And is a logical conjunction, the right side is only evaluated when the left side is not false.
+// It follows SQL three-valued logic: false wins over NULL, and NULL wins over true.
+type And struct {
+	Left  Expression
+	Right Expression
+}
+
+func NewAnd(left, right Expression) (*And, error) {
+	if err := checkBoolean("AND", left, right); err != nil {
+		return nil, err
+	}
+	return &And{Left: left, Right: right}, nil
+}
+
+func (a *And) Type() types.Type {
+	return types.TypeBoolean
+}
+
+func (a *And) Evaluate(t *types.Row) (types.Value, error) {
+	left, leftKnown, err := truth(a.Left, t)
+	if err != nil {
+		return nil, err
+	}
+	if leftKnown && !left {
+		return types.NewBoolean(false), nil
+	}
+	right, rightKnown, err := truth(a.Right, t)
+	if err != nil {
+		return nil, err
+	}
+	if rightKnown && !right {
+		return types.NewBoolean(false), nil
+	}
+	if !leftKnown || !rightKnown {
+		return types.NewNull(types.TypeBoolean), nil
+	}
+	return types.NewBoolean(true), nil
+}
+
+func (a *And) Check(schema types.TableSchema) error {
+	if err := a.Left.Check(schema); err != nil {
+		return err
+	}
+	return a.Right.Check(schema)
+}
+
+func (a *And) String() string {
+	return fmt.Sprintf("And(%s, %s)", a.Left, a.Right)
+}
+
+func (a *And) Children() []Expression {
+	return []Expression{a.Left, a.Right}
+}
+
+func (a *And) Attributes() []Attribute {
+	return nil
+}
+
+// Or is a logical disjunction, the right side is only evaluated when the left side is not true.
+// It follows SQL three-valued logic: true wins over NULL, and NULL wins over false.
+type Or struct {
+	Left  Expression
+	Right Expression
+}
+
+func NewOr(left, right Expression) (*Or, error) {
+	if err := checkBoolean("OR", left, right); err != nil {
+		return nil, err
+	}
+	return &Or{Left: left, Right: right}, nil
+}
+
+func (o *Or) Type() types.Type {
+	return types.TypeBoolean
+}
+
+func (o *Or) Evaluate(t *types.Row) (types.Value, error) {
+	left, leftKnown, err := truth(o.Left, t)
+	if err != nil {
+		return nil, err
+	}
+	if leftKnown && left {
+		return types.NewBoolean(true), nil
+	}
+	right, rightKnown, err := truth(o.Right, t)
+	if err != nil {
+		return nil, err
+	}
+	if rightKnown && right {
+		return types.NewBoolean(true), nil
+	}
+	if !leftKnown || !rightKnown {
+		return types.NewNull(types.TypeBoolean), nil
+	}
+	return types.NewBoolean(false), nil
+}
+
+func (o *Or) Check(schema types.TableSchema) error {
+	if err := o.Left.Check(schema); err != nil {
+		return err
+	}
+	return o.Right.Check(schema)
+}
+
+func (o *Or) String() string {
+	return fmt.Sprintf("Or(%s, %s)", o.Left, o.Right)
+}
+
+func (o *Or) Children() []Expression {
+	return []Expression{o.Left, o.Right}
+}
+
+func (o *Or) Attributes() []Attribute {
+	return nil
+}
+
+// Not is a logical negation, the negation of NULL is NULL
+type Not struct {
+	Expression Expression
+}
+
+func NewNot(expression Expression) (*Not, error) {
+	if err := checkBoolean("NOT", expression); err != nil {
+		return nil, err
+	}
+	return &Not{Expression: expression}, nil
+}
+
+func (n *Not) Type() types.Type {
+	return types.TypeBoolean
+}
+
+func (n *Not) Evaluate(t *types.Row) (types.Value, error) {
+	value, known, err := truth(n.Expression, t)
+	if err != nil {
+		return nil, err
+	}
+	if !known {
+		return types.NewNull(types.TypeBoolean), nil
+	}
+	return types.NewBoolean(!value), nil
+}
+
+func (n *Not) Check(schema types.TableSchema) error {
+	return n.Expression.Check(schema)
+}
+
+func (n *Not) String() string {
+	return fmt.Sprintf("Not(%s)", n.Expression)
+}
+
+func (n *Not) Children() []Expression {
+	return []Expression{n.Expression}
+}
+
+func (n *Not) Attributes() []Attribute {
+	return nil
+}
+
+func checkBoolean(operator string, operands ...Expression) error {
+	for _, operand := range operands {
+		if operand.Type() != types.TypeBoolean {
+			return fmt.Errorf("operand of %s must be a boolean expression, got %v: %s", operator, operand.Type(), operand)
+		}
+	}
+	return nil
+}
+
+// truth evaluates a boolean expression, known is false when the value is NULL
+func truth(e Expression, t *types.Row) (value, known bool, err error) {
+	v, err := e.Evaluate(t)
+	if err != nil || types.IsNull(v) {
+		return false, false, err
+	}
+	b, ok := v.(types.Boolean)
+	if !ok {
+		return false, false, fmt.Errorf("%s: expected a boolean value, got %v", e, v)
+	}
+	return b.Bool(), true, nil
+}
+
+// isTrue reports whether a condition holds, NULL is treated as false as in WHERE and ON clauses
+func isTrue(condition Expression, t *types.Row) (bool, error) {
+	value, known, err := truth(condition, t)
+	return known && value, err
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"testing"
+)
+
+// panicking is an expression that fails the test when evaluated, to check short-circuiting
+type panicking struct {
+	t *testing.T
+}
+
+func (p panicking) Type() types.Type {
+	return types.TypeBoolean
+}
+
+func (p panicking) Evaluate(*types.Row) (types.Value, error) {
+	p.t.Fatalf("expression should not have been evaluated")
+	return nil, nil
+}
+
+func (p panicking) Check(types.TableSchema) error {
+	return nil
+}
+
+func (p panicking) String() string {
+	return "panicking"
+}
+
+func (p panicking) Children() []Expression {
+	return nil
+}
+
+func (p panicking) Attributes() []Attribute {
+	return nil
+}
+
+func TestLogicalOperations(t *testing.T) {
+	column := NewColumnReference(0, types.TypeBoolean)
+	yes := NewConstant(types.NewBoolean(true))
+	no := NewConstant(types.NewBoolean(false))
+
+	cases := []struct {
+		name string
+		expr func() (Expression, error)
+		want bool
+	}{
+		{
+			name: "true and column",
+			expr: func() (Expression, error) { return NewAnd(yes, column) },
+			want: true,
+		},
+		{
+			name: "false and short-circuits",
+			expr: func() (Expression, error) { return NewAnd(no, panicking{t}) },
+			want: false,
+		},
+		{
+			name: "false or column",
+			expr: func() (Expression, error) { return NewOr(no, column) },
+			want: true,
+		},
+		{
+			name: "true or short-circuits",
+			expr: func() (Expression, error) { return NewOr(yes, panicking{t}) },
+			want: true,
+		},
+		{
+			name: "not column",
+			expr: func() (Expression, error) { return NewNot(column) },
+			want: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := c.expr()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := expr.Check(sampleSchema()); err != nil {
+				t.Fatalf("unexpected error in Check: %v", err)
+			}
+			got, err := expr.Evaluate(sampleRow())
+			if err != nil {
+				t.Fatalf("%s.Evaluate() = %v, want nil", expr, err)
+			}
+			if got != types.NewBoolean(c.want) {
+				t.Errorf("%s.Evaluate() = %v, want %v", expr, got, c.want)
+			}
+		})
+	}
+}
+
+func TestLogicalOperationsInvalid(t *testing.T) {
+	text := NewColumnReference(1, types.TypeText)
+	yes := NewConstant(types.NewBoolean(true))
+
+	if _, err := NewAnd(yes, text); err == nil {
+		t.Errorf("NewAnd() with text operand returned nil, want error")
+	}
+	if _, err := NewOr(text, yes); err == nil {
+		t.Errorf("NewOr() with text operand returned nil, want error")
+	}
+	if _, err := NewNot(text); err == nil {
+		t.Errorf("NewNot() with text operand returned nil, want error")
+	}
+}
+
+func TestThreeValuedLogic(t *testing.T) {
+	yes := NewConstant(types.NewBoolean(true))
+	no := NewConstant(types.NewBoolean(false))
+	unknown := NewConstant(types.NewNull(types.TypeBoolean))
+	null := types.NewNull(types.TypeBoolean)
+
+	cases := []struct {
+		name string
+		expr func() (Expression, error)
+		want types.Value
+	}{
+		{
+			name: "true and null",
+			expr: func() (Expression, error) { return NewAnd(yes, unknown) },
+			want: null,
+		},
+		{
+			name: "null and false",
+			expr: func() (Expression, error) { return NewAnd(unknown, no) },
+			want: types.NewBoolean(false),
+		},
+		{
+			name: "false or null",
+			expr: func() (Expression, error) { return NewOr(no, unknown) },
+			want: null,
+		},
+		{
+			name: "null or true",
+			expr: func() (Expression, error) { return NewOr(unknown, yes) },
+			want: types.NewBoolean(true),
+		},
+		{
+			name: "not null",
+			expr: func() (Expression, error) { return NewNot(unknown) },
+			want: null,
+		},
+		{
+			name: "null equals null",
+			expr: func() (Expression, error) { return NewBinaryOperation(unknown, unknown, BinaryOperatorEq) },
+			want: null,
+		},
+		{
+			name: "null is null",
+			expr: func() (Expression, error) { return NewIsNull(unknown, false), nil },
+			want: types.NewBoolean(true),
+		},
+		{
+			name: "true is not null",
+			expr: func() (Expression, error) { return NewIsNull(yes, true), nil },
+			want: types.NewBoolean(true),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := c.expr()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := expr.Evaluate(sampleRow())
+			if err != nil {
+				t.Fatalf("%s.Evaluate() = %v, want nil", expr, err)
+			}
+			if got != c.want {
+				t.Errorf("%s.Evaluate() = %v, want %v", expr, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+)
+
+// MergeJoin joins two inputs that are both sorted in ascending order of their keys, NULL keys may be placed
+// first or last. Only the right rows sharing the key of the current left row are kept in memory.
+// Condition, when not nil, must also hold for a pair of rows with equal keys to match.
+type MergeJoin struct {
+	Type           JoinType
+	Left           QueryPlan
+	Right          QueryPlan
+	LeftKeys       []Expression
+	RightKeys      []Expression
+	Condition      Expression
+	combinedSchema types.TableSchema
+}
+
+func NewMergeJoin(t JoinType, left, right QueryPlan, leftKeys, rightKeys []Expression, condition Expression) (*MergeJoin, error) {
+	if t < JoinTypeInner || t > JoinTypeFullOuter {
+		return nil, fmt.Errorf("unsupported join type %v", t)
+	}
+	if err := checkJoinKeys(left, right, leftKeys, rightKeys); err != nil {
+		return nil, err
+	}
+	if err := checkResidualCondition(left, right, condition); err != nil {
+		return nil, err
+	}
+	return &MergeJoin{
+		Type:           t,
+		Left:           left,
+		Right:          right,
+		LeftKeys:       leftKeys,
+		RightKeys:      rightKeys,
+		Condition:      condition,
+		combinedSchema: CombineSchemas(nullableIf(left.Schema(), t.preservesRight()), nullableIf(right.Schema(), t.preservesLeft())),
+	}, nil
+}
+
+func (j *MergeJoin) Schema() types.TableSchema {
+	return j.combinedSchema
+}
+
+func (j *MergeJoin) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	inputs, err := openAll(ctx, db, j.Left, j.Right)
+	if err != nil {
+		return nil, err
+	}
+	it := &mergeJoinIterator{
+		join:       j,
+		schema:     j.Schema(),
+		left:       &mergeInput{rows: inputs[0], schema: j.Left.Schema(), keys: j.LeftKeys},
+		right:      &mergeInput{rows: inputs[1], schema: j.Right.Schema(), keys: j.RightKeys},
+		leftNulls:  nullRow(j.Left.Schema()),
+		rightNulls: nullRow(j.Right.Schema()),
+	}
+	if err := it.left.advance(); err != nil {
+		it.Close()
+		return nil, err
+	}
+	if err := it.right.advance(); err != nil {
+		it.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+func (j *MergeJoin) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, j, db)
+}
+
+func (j *MergeJoin) Print(printer *Printer) {
+	printer.Println("MergeJoin {")
+	printer.Indent()
+	printer.Println("Type: %s", j.Type)
+	printer.Println("Left:")
+	j.Left.Print(printer)
+	printer.Println("Right:")
+	j.Right.Print(printer)
+	printer.Println("Keys:")
+	printer.Indent()
+	for i := range j.LeftKeys {
+		printer.Println("%s = %s", j.LeftKeys[i], j.RightKeys[i])
+	}
+	printer.Dedent()
+	if j.Condition != nil {
+		printer.Println("Condition: %s", j.Condition)
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (j *MergeJoin) Children() []QueryPlan {
+	return []QueryPlan{j.Left, j.Right}
+}
+
+func (j *MergeJoin) Attributes() []Attribute {
+	attributes := []Attribute{
+		{Name: "Type", Value: j.Type.String()},
+		{Name: "LeftKeys", Value: j.LeftKeys},
+		{Name: "RightKeys", Value: j.RightKeys},
+	}
+	if j.Condition != nil {
+		attributes = append(attributes, Attribute{Name: "Condition", Value: j.Condition})
+	}
+	return attributes
+}
+
+// mergeInput is one side of a merge join, positioned on the next row that has not been joined yet
+type mergeInput struct {
+	rows   RowIterator
+	schema types.TableSchema
+	keys   []Expression
+	// row is nil once the input is exhausted, rowKeys is nil when any key of the row is NULL
+	row      []types.Value
+	rowKeys  []types.Value
+	lastKeys []types.Value
+}
+
+func (m *mergeInput) advance() error {
+	var err error
+	m.rowKeys = nil
+	m.row, err = m.rows.Next()
+	if err != nil || m.row == nil {
+		return err
+	}
+	m.rowKeys, err = evaluateJoinKeys(m.keys, &types.Row{Schema: m.schema, Values: m.row})
+	if err != nil || m.rowKeys == nil {
+		return err
+	}
+	if m.lastKeys != nil && compareJoinKeys(m.lastKeys, m.rowKeys) > 0 {
+		return fmt.Errorf("merge join input is not sorted on %v: %v follows %v", m.keys, m.rowKeys, m.lastKeys)
+	}
+	m.lastKeys = m.rowKeys
+	return nil
+}
+
+type mergeJoinIterator struct {
+	join        *MergeJoin
+	schema      types.TableSchema
+	left, right *mergeInput
+	leftNulls   []types.Value
+	rightNulls  []types.Value
+
+	// group holds the right rows whose keys equal groupKeys, every left row with the same keys is joined with them
+	group        [][]types.Value
+	groupKeys    []types.Value
+	groupMatched []bool
+	groupSize    int
+
+	// pending holds the rows produced by the last step, at most one for every row of the group
+	pending [][]types.Value
+}
+
+func (j *mergeJoinIterator) Next() ([]types.Value, error) {
+	for len(j.pending) == 0 {
+		more, err := j.step()
+		if err != nil || !more {
+			return nil, err
+		}
+	}
+	row := j.pending[0]
+	j.pending = j.pending[1:]
+	return row, nil
+}
+
+// step consumes at least one input row, it returns false once both inputs are exhausted
+func (j *mergeJoinIterator) step() (bool, error) {
+	left, right := j.left, j.right
+	if j.groupKeys != nil {
+		if left.rowKeys != nil && compareJoinKeys(left.rowKeys, j.groupKeys) == 0 {
+			if err := j.joinGroup(left.row); err != nil {
+				return false, err
+			}
+			return true, left.advance()
+		}
+		j.closeGroup()
+	}
+
+	switch {
+	case left.row == nil && right.row == nil:
+		return false, nil
+	// NULL never equals anything, so rows with a NULL key can only be returned unmatched
+	case left.row != nil && (left.rowKeys == nil || right.row == nil):
+		j.unmatchedLeft(left.row)
+		return true, left.advance()
+	case right.row != nil && (right.rowKeys == nil || left.row == nil):
+		j.unmatchedRight(right.row)
+		return true, right.advance()
+	}
+	switch c := compareJoinKeys(left.rowKeys, right.rowKeys); {
+	case c < 0:
+		j.unmatchedLeft(left.row)
+		return true, left.advance()
+	case c > 0:
+		j.unmatchedRight(right.row)
+		return true, right.advance()
+	}
+	return true, j.openGroup()
+}
+
+// openGroup reads every right row with the keys of the current right row
+func (j *mergeJoinIterator) openGroup() error {
+	right := j.right
+	j.groupKeys = right.rowKeys
+	for right.rowKeys != nil && compareJoinKeys(right.rowKeys, j.groupKeys) == 0 {
+		j.group = append(j.group, right.row)
+		if err := right.advance(); err != nil {
+			return err
+		}
+	}
+	j.groupMatched = make([]bool, len(j.group))
+	return nil
+}
+
+func (j *mergeJoinIterator) joinGroup(leftRow []types.Value) error {
+	matched := false
+	for i, rightRow := range j.group {
+		values := combinedRows(leftRow, rightRow)
+		if j.join.Condition != nil {
+			ok, err := isTrue(j.join.Condition, &types.Row{Schema: j.schema, Values: values})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		matched = true
+		j.groupMatched[i] = true
+		j.pending = append(j.pending, values)
+	}
+	if !matched {
+		j.unmatchedLeft(leftRow)
+	}
+	return nil
+}
+
+func (j *mergeJoinIterator) closeGroup() {
+	for i, rightRow := range j.group {
+		if !j.groupMatched[i] {
+			j.unmatchedRight(rightRow)
+		}
+	}
+	j.group, j.groupKeys, j.groupMatched, j.groupSize = nil, nil, nil, 0
+}
+
+func (j *mergeJoinIterator) memory() int {
+	if j.groupSize == 0 {
+		j.groupSize = rowsMemory(j.group)
+	}
+	return j.groupSize
+}
+
+func (j *mergeJoinIterator) unmatchedLeft(row []types.Value) {
+	if j.join.Type.preservesLeft() {
+		j.pending = append(j.pending, combinedRows(row, j.rightNulls))
+	}
+}
+
+func (j *mergeJoinIterator) unmatchedRight(row []types.Value) {
+	if j.join.Type.preservesRight() {
+		j.pending = append(j.pending, combinedRows(j.leftNulls, row))
+	}
+}
+
+func (j *mergeJoinIterator) Close() {
+	j.left.rows.Close()
+	j.right.rows.Close()
+	j.group, j.pending = nil, nil
+}
+
+// compareJoinKeys orders two lists of non NULL keys in ascending order
+func compareJoinKeys(a, b []types.Value) int {
+	for i := range a {
+		switch a[i].Compare(b[i]) {
+		case types.ComparisonLess:
+			return -1
+		case types.ComparisonGreater:
+			return 1
+		}
+	}
+	return 0
+}
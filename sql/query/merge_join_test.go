@@ -0,0 +1,106 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func TestMergeJoin(t *testing.T) {
+	db, schema := hashJoinDatabase(t)
+	id := NewColumnReference(0, types.TypeDecimal)
+	eq := func(left, right Expression) Expression {
+		e, err := NewBinaryOperation(left, right, BinaryOperatorEq)
+		if err != nil {
+			t.Fatalf("unexpected error in NewBinaryOperation: %v", err)
+		}
+		return e
+	}
+	namesEqual := eq(NewColumnReference(1, types.TypeText), NewColumnReference(3, types.TypeText))
+	idsEqual := eq(id, NewColumnReference(2, types.TypeDecimal))
+	sorted := func(table string, nullsFirst bool) QueryPlan {
+		s, err := NewSort(NewLoad(table, schema), []SortKey{{Expression: id, NullsFirst: nullsFirst}})
+		if err != nil {
+			t.Fatalf("unexpected error in NewSort: %v", err)
+		}
+		return s
+	}
+
+	cases := []struct {
+		name      string
+		residual  Expression
+		condition Expression
+	}{
+		{name: "single key", condition: idsEqual},
+		{name: "residual condition", residual: namesEqual, condition: &And{Left: idsEqual, Right: namesEqual}},
+	}
+
+	for _, c := range cases {
+		for _, joinType := range []JoinType{JoinTypeInner, JoinTypeLeftOuter, JoinTypeRightOuter, JoinTypeFullOuter} {
+			for _, nullsFirst := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s %s nulls first %v", c.name, joinType, nullsFirst), func(t *testing.T) {
+					loop, err := NewJoin(joinType, NewLoad("l", schema), NewLoad("r", schema), c.condition)
+					if err != nil {
+						t.Fatalf("unexpected error in NewJoin: %v", err)
+					}
+					merge, err := NewMergeJoin(joinType, sorted("l", nullsFirst), sorted("r", !nullsFirst), []Expression{id}, []Expression{id}, c.residual)
+					if err != nil {
+						t.Fatalf("unexpected error in NewMergeJoin: %v", err)
+					}
+					if !reflect.DeepEqual(merge.Schema(), loop.Schema()) {
+						t.Errorf("merge.Schema() = %v, want %v", merge.Schema(), loop.Schema())
+					}
+					got, want := sortedRows(run(t, merge, db).Rows), sortedRows(run(t, loop, db).Rows)
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("merge.Run() = %v, want %v", got, want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestMergeJoinDuplicates(t *testing.T) {
+	db, schema := hashJoinDatabase(t)
+	id := NewColumnReference(0, types.TypeDecimal)
+	sorted := func(table string) QueryPlan {
+		s, err := NewSort(NewLoad(table, schema), []SortKey{{Expression: id}, {Expression: NewColumnReference(1, types.TypeText)}})
+		if err != nil {
+			t.Fatalf("unexpected error in NewSort: %v", err)
+		}
+		return s
+	}
+	merge, err := NewMergeJoin(JoinTypeInner, sorted("l"), sorted("r"), []Expression{id}, []Expression{id}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error in NewMergeJoin: %v", err)
+	}
+
+	var got []string
+	for _, row := range run(t, merge, db).Rows {
+		got = append(got, fmt.Sprintf("%v%v", row[1], row[3]))
+	}
+	// every left row is joined with the whole group of right rows with the same key, in the order of both inputs
+	want := []string{`"a""a"`, `"b""b"`, `"b""x"`, `"c""b"`, `"c""x"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merge.Run() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeJoinUnsortedInput(t *testing.T) {
+	db, schema := hashJoinDatabase(t)
+	id := NewColumnReference(0, types.TypeDecimal)
+	descending, err := NewSort(NewLoad("l", schema), []SortKey{{Expression: id, Descending: true}})
+	if err != nil {
+		t.Fatalf("unexpected error in NewSort: %v", err)
+	}
+	merge, err := NewMergeJoin(JoinTypeInner, descending, NewLoad("r", schema), []Expression{id}, []Expression{id}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error in NewMergeJoin: %v", err)
+	}
+
+	if _, err := merge.Run(context.Background(), db); err == nil {
+		t.Errorf("merge.Run() with unsorted input returned nil, want error")
+	}
+}
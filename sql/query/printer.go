@@ -33,3 +33,8 @@ func (p *Printer) Println(format string, a ...any) {
 	fmt.Fprintln(p.builder)
 
 }
+
+// String returns everything printed so far
+func (p *Printer) String() string {
+	return p.builder.String()
+}
@@ -1,19 +1,30 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"github.com/Vignesh-Rajarajan/go-db/storage"
 	"github.com/Vignesh-Rajarajan/go-db/types"
+	"strings"
 )
 
 type QueryPlan interface {
 	Schema() types.TableSchema
-	Run(db *storage.Database) *types.Relation
+	// Open starts streaming the rows of the plan
+	Open(ctx context.Context, db *storage.Database) (RowIterator, error)
+	// Run returns every row of the plan at once, see Materialize
+	Run(ctx context.Context, db *storage.Database) (*types.Relation, error)
 	Print(printer *Printer)
+	// Children returns the inputs of the plan, in the order they are printed
+	Children() []QueryPlan
+	// Attributes returns the properties of the plan other than its inputs, see Attribute
+	Attributes() []Attribute
 }
 
 type Load struct {
-	TableName   string
+	TableName string
+	// Alias is the name the columns are prefixed with when the table is renamed in the query
+	Alias       string
 	TableSchema types.TableSchema
 }
 
@@ -21,30 +32,53 @@ func NewLoad(tableName string, tableSchema types.TableSchema) *Load {
 	return &Load{TableName: tableName, TableSchema: tableSchema.Prefix(tableName)}
 }
 
+// NewAliasedLoad loads a table under another name, so that the same table can appear twice in a join
+func NewAliasedLoad(tableName, alias string, tableSchema types.TableSchema) *Load {
+	return &Load{TableName: tableName, Alias: alias, TableSchema: tableSchema.Prefix(alias)}
+}
+
 func (l *Load) Schema() types.TableSchema {
 	return l.TableSchema
 
 }
 
-func (l *Load) Run(db *storage.Database) *types.Relation {
+func (l *Load) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
 	r, err := db.GetTable(l.TableName)
 	if err != nil {
-		panic(fmt.Errorf("table %s not found", l.TableName))
+		return nil, fmt.Errorf("table %s not found", l.TableName)
 	}
-	return r
+	return &sliceIterator{ctx: ctx, rows: r.Rows}, nil
+}
 
+func (l *Load) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, l, db)
 }
 
 func (l *Load) Print(printer *Printer) {
 	printer.Println("Load {")
 	printer.Indent()
 	printer.Println("Table: %q", l.TableName)
-	printer.Println("Schema: %s", l.TableSchema)
+	if l.Alias != "" {
+		printer.Println("Alias: %q", l.Alias)
+	}
+	printer.Println("Schema: %s", &l.TableSchema)
 	printer.Dedent()
 	printer.Println("}")
 
 }
 
+func (l *Load) Children() []QueryPlan {
+	return nil
+}
+
+func (l *Load) Attributes() []Attribute {
+	attributes := []Attribute{{Name: "Table", Value: l.TableName}}
+	if l.Alias != "" {
+		attributes = append(attributes, Attribute{Name: "Alias", Value: l.Alias})
+	}
+	return append(attributes, Attribute{Name: "Schema", Value: l.TableSchema.String()})
+}
+
 type Select struct {
 	From      QueryPlan
 	Condition Expression
@@ -66,21 +100,42 @@ func (s *Select) Schema() types.TableSchema {
 	return s.From.Schema()
 }
 
-func (s *Select) Run(db *storage.Database) *types.Relation {
-	from := s.From.Run(db)
+func (s *Select) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	from, err := s.From.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return &selectIterator{from: from, schema: s.Schema(), condition: s.Condition}, nil
+}
 
-	var rows [][]types.Value
-	for i := range from.Rows {
-		row := from.Row(i)
-		res := s.Condition.Evaluate(row).(types.Boolean)
-		if res.Bool() {
-			rows = append(rows, row.Values)
+func (s *Select) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, s, db)
+}
+
+type selectIterator struct {
+	from      RowIterator
+	schema    types.TableSchema
+	condition Expression
+}
+
+func (s *selectIterator) Next() ([]types.Value, error) {
+	for {
+		values, err := s.from.Next()
+		if err != nil || values == nil {
+			return nil, err
+		}
+		ok, err := isTrue(s.condition, &types.Row{Schema: s.schema, Values: values})
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return values, nil
 		}
 	}
-	return &types.Relation{
-		Schema: from.Schema,
-		Rows:   rows,
-	}
+}
+
+func (s *selectIterator) Close() {
+	s.from.Close()
 }
 
 func (s *Select) Print(printer *Printer) {
@@ -93,6 +148,14 @@ func (s *Select) Print(printer *Printer) {
 	printer.Println("}")
 }
 
+func (s *Select) Children() []QueryPlan {
+	return []QueryPlan{s.From}
+}
+
+func (s *Select) Attributes() []Attribute {
+	return []Attribute{{Name: "Condition", Value: s.Condition}}
+}
+
 type OutputColumn struct {
 	Name       string
 	Expression Expression
@@ -146,27 +209,66 @@ func NewProject(from QueryPlan, columns []OutputColumn) (*Project, error) {
 }
 
 func (p *Project) Schema() types.TableSchema {
+	from := p.From.Schema()
 	var columns []types.ColumnSchema
 	for _, c := range p.Columns {
-		columns = append(columns, c.Schema())
+		column := c.Schema()
+		column.Nullable = nullable(c.Expression, from)
+		columns = append(columns, column)
 	}
 	return types.TableSchema{Columns: columns}
 }
 
-func (p *Project) Run(db *storage.Database) *types.Relation {
-	from := p.From.Run(db)
-	var rows = make([][]types.Value, len(from.Rows))
-	for i := range from.Rows {
-		row := make([]types.Value, len(p.Columns))
-		for j := range p.Columns {
-			row[j] = p.Columns[j].Expression.Evaluate(from.Row(i))
-		}
-		rows[i] = row
+// nullable reports whether an expression may evaluate to NULL. Column references inherit the flag of
+// their column, any other computed expression is conservatively assumed to be nullable.
+func nullable(e Expression, schema types.TableSchema) bool {
+	switch e := e.(type) {
+	case ColumnReference:
+		return schema.Columns[e.Index].Nullable
+	case Constant:
+		return types.IsNull(e.Value)
+	case *IsNull:
+		return false
+	}
+	return true
+}
+
+func (p *Project) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	from, err := p.From.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return &projectIterator{from: from, schema: p.From.Schema(), columns: p.Columns}, nil
+}
+
+func (p *Project) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, p, db)
+}
+
+type projectIterator struct {
+	from    RowIterator
+	schema  types.TableSchema
+	columns []OutputColumn
+}
+
+func (p *projectIterator) Next() ([]types.Value, error) {
+	values, err := p.from.Next()
+	if err != nil || values == nil {
+		return nil, err
 	}
-	return &types.Relation{
-		Schema: p.Schema(),
-		Rows:   rows,
+	from := &types.Row{Schema: p.schema, Values: values}
+	row := make([]types.Value, len(p.columns))
+	for i, c := range p.columns {
+		row[i], err = c.Expression.Evaluate(from)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", c.Name, err)
+		}
 	}
+	return row, nil
+}
+
+func (p *projectIterator) Close() {
+	p.from.Close()
 }
 
 func (p *Project) Print(printer *Printer) {
@@ -183,3 +285,140 @@ func (p *Project) Print(printer *Printer) {
 	printer.Dedent()
 	printer.Println("}")
 }
+
+func (p *Project) Children() []QueryPlan {
+	return []QueryPlan{p.From}
+}
+
+func (p *Project) Attributes() []Attribute {
+	return []Attribute{{Name: "Columns", Value: outputColumnAttributes(p.Columns)}}
+}
+
+// Values produces a fixed list of rows, such as those given by INSERT INTO ... VALUES
+type Values struct {
+	TableSchema types.TableSchema
+	Rows        [][]Expression
+}
+
+func NewValues(schema types.TableSchema, rows [][]Expression) (*Values, error) {
+	for _, row := range rows {
+		if len(row) != len(schema.Columns) {
+			return nil, fmt.Errorf("wrong number of values: expected %d values, got %d", len(schema.Columns), len(row))
+		}
+		for i, e := range row {
+			// values cannot reference any column
+			if err := e.Check(types.TableSchema{}); err != nil {
+				return nil, err
+			}
+			column := schema.Columns[i]
+			if e.Type() != column.Type && e.Type() != types.TypeNull {
+				return nil, fmt.Errorf("mismatched types: column %s is of type %v, got %v: %s", column.Name, column.Type, e.Type(), e)
+			}
+		}
+	}
+	return &Values{TableSchema: schema, Rows: rows}, nil
+}
+
+func (v *Values) Schema() types.TableSchema {
+	return v.TableSchema
+}
+
+func (v *Values) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	return &valuesIterator{ctx: ctx, rows: v.Rows}, nil
+}
+
+func (v *Values) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, v, db)
+}
+
+// valuesIterator evaluates each row of a Values when it is requested
+type valuesIterator struct {
+	ctx  context.Context
+	rows [][]Expression
+}
+
+func (v *valuesIterator) Next() ([]types.Value, error) {
+	if err := checkCanceled(v.ctx); err != nil {
+		return nil, err
+	}
+	if len(v.rows) == 0 {
+		return nil, nil
+	}
+	row := v.rows[0]
+	v.rows = v.rows[1:]
+	values := make([]types.Value, len(row))
+	for i, e := range row {
+		value, err := e.Evaluate(&types.Row{})
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (v *valuesIterator) Close() {
+	v.rows = nil
+}
+
+func (v *Values) Print(printer *Printer) {
+	printer.Println("Values {")
+	printer.Indent()
+	for _, row := range v.Rows {
+		values := make([]string, len(row))
+		for i, e := range row {
+			values[i] = e.String()
+		}
+		printer.Println("(%s)", strings.Join(values, ", "))
+	}
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (v *Values) Children() []QueryPlan {
+	return nil
+}
+
+func (v *Values) Attributes() []Attribute {
+	return []Attribute{{Name: "Schema", Value: v.TableSchema.String()}, {Name: "Rows", Value: v.Rows}}
+}
+
+// Empty produces no rows. From is only kept for its schema and is never opened, so that a query
+// known to return nothing does not read any table.
+type Empty struct {
+	From QueryPlan
+}
+
+func NewEmpty(from QueryPlan) *Empty {
+	return &Empty{From: from}
+}
+
+func (e *Empty) Schema() types.TableSchema {
+	return e.From.Schema()
+}
+
+func (e *Empty) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	return &sliceIterator{ctx: ctx}, nil
+}
+
+func (e *Empty) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, e, db)
+}
+
+func (e *Empty) Print(printer *Printer) {
+	schema := e.Schema()
+	printer.Println("Empty {")
+	printer.Indent()
+	printer.Println("Schema: %s", &schema)
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (e *Empty) Children() []QueryPlan {
+	return nil
+}
+
+func (e *Empty) Attributes() []Attribute {
+	schema := e.Schema()
+	return []Attribute{{Name: "Schema", Value: schema.String()}}
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"github.com/Vignesh-Rajarajan/go-db/storage"
 	"github.com/Vignesh-Rajarajan/go-db/types"
 	"reflect"
@@ -30,11 +31,21 @@ func sampleDatabase(t *testing.T) *storage.Database {
 	return db
 }
 
+// run materializes a plan, failing the test on runtime errors
+func run(t *testing.T, plan QueryPlan, db *storage.Database) *types.Relation {
+	t.Helper()
+	relation, err := plan.Run(context.Background(), db)
+	if err != nil {
+		t.Fatalf("%T.Run() = %v, want nil", plan, err)
+	}
+	return relation
+}
+
 func TestNewLoad(t *testing.T) {
 	schema := sampleSchema()
 	db := sampleDatabase(t)
 	l := NewLoad("mytable", schema)
-	got := l.Run(db)
+	got := run(t, l, db)
 	want := &types.Relation{
 		Schema: schema,
 		Rows:   sampleRows(),
@@ -61,7 +72,7 @@ func TestNewSelect(t *testing.T) {
 	if err != nil {
 		t.Fatalf("NewSelect() = %v, want nil", err)
 	}
-	got := s.Run(db)
+	got := run(t, s, db)
 	want := &types.Relation{
 		Schema: schema,
 		Rows:   [][]types.Value{sampleRows()[1]},
@@ -70,3 +81,65 @@ func TestNewSelect(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func TestRunErrors(t *testing.T) {
+	schema := sampleSchema()
+	db := sampleDatabase(t)
+	one := NewConstant(types.NewDecimal("1"))
+	zero := NewConstant(types.NewDecimal("0"))
+	division, err := NewArithmeticOperation(one, zero, ArithmeticOperatorDiv)
+	if err != nil {
+		t.Fatalf("NewArithmeticOperation() = %v, want nil", err)
+	}
+	project, err := NewProject(NewLoad("mytable", schema), []OutputColumn{ComputedColumn("ratio", division)})
+	if err != nil {
+		t.Fatalf("NewProject() = %v, want nil", err)
+	}
+	condition, err := NewBinaryOperation(division, one, BinaryOperatorGt)
+	if err != nil {
+		t.Fatalf("NewBinaryOperation() = %v, want nil", err)
+	}
+	selection, err := NewSelect(NewLoad("mytable", schema), condition)
+	if err != nil {
+		t.Fatalf("NewSelect() = %v, want nil", err)
+	}
+
+	cases := []struct {
+		name string
+		plan QueryPlan
+		want string
+	}{
+		{name: "missing table", plan: NewLoad("missing", schema), want: "table missing not found"},
+		{name: "division by zero in projection", plan: project, want: "column ratio: ArithmeticOperation(1 div 0): division by zero"},
+		{name: "division by zero in condition", plan: selection, want: "ArithmeticOperation(1 div 0): division by zero"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.plan.Run(context.Background(), db)
+			if err == nil || err.Error() != c.want {
+				t.Errorf("Run() = %v, want %s", err, c.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	// the column holds text, which Check would have rejected
+	text := NewColumnReference(1, types.TypeBoolean)
+	cases := []struct {
+		name string
+		expr Expression
+	}{
+		{name: "non boolean operand", expr: &And{Left: NewConstant(types.NewBoolean(true)), Right: text}},
+		{name: "incomparable operands", expr: &BinaryOperation{Left: text, Right: NewConstant(types.NewBoolean(true)), Operator: BinaryOperatorEq}},
+		{name: "non decimal operand", expr: &Negation{Expression: text}},
+		{name: "column out of range", expr: NewColumnReference(2, types.TypeText)},
+	}
+
+	for _, c := range cases {
+		if got, err := c.expr.Evaluate(sampleRow()); err == nil {
+			t.Errorf("%s: %s.Evaluate() = %v, want error", c.name, c.expr, got)
+		}
+	}
+}
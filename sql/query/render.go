@@ -0,0 +1,138 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RenderDOT returns a Graphviz digraph of a plan, with one box per node listing its attributes
+// and an edge from every node to each of its inputs
+func RenderDOT(plan QueryPlan) string {
+	builder := new(strings.Builder)
+	fmt.Fprintln(builder, "digraph plan {")
+	fmt.Fprintln(builder, "  node [shape=box, fontname=\"monospace\"];")
+	Walk(&dotVisitor{builder: builder, next: new(int), parent: -1}, plan)
+	fmt.Fprintln(builder, "}")
+	return builder.String()
+}
+
+// dotVisitor writes a node and the edge from its parent, the visitor returned for the inputs has the node as parent
+type dotVisitor struct {
+	builder *strings.Builder
+	next    *int
+	parent  int
+}
+
+func (d *dotVisitor) Visit(plan QueryPlan) Visitor {
+	id := *d.next
+	*d.next++
+	lines := []string{NodeName(plan)}
+	for _, a := range plan.Attributes() {
+		lines = append(lines, fmt.Sprintf("%s: %s", a.Name, formatAttribute(a.Value)))
+	}
+	// \l ends a left aligned line of a label
+	label := strings.ReplaceAll(strings.Join(lines, "\n"), `\`, `\\`)
+	label = strings.ReplaceAll(label, `"`, `\"`)
+	label = strings.ReplaceAll(label, "\n", `\l`) + `\l`
+	fmt.Fprintf(d.builder, "  n%d [label=\"%s\"];\n", id, label)
+	if d.parent >= 0 {
+		fmt.Fprintf(d.builder, "  n%d -> n%d;\n", d.parent, id)
+	}
+	return &dotVisitor{builder: d.builder, next: d.next, parent: id}
+}
+
+// formatAttribute writes the value of an attribute on a single line, expressions are written with their String method
+func formatAttribute(value any) string {
+	switch v := value.(type) {
+	case []Expression:
+		values := make([]string, len(v))
+		for i, e := range v {
+			values[i] = formatAttribute(e)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case [][]Expression:
+		values := make([]string, len(v))
+		for i, e := range v {
+			values[i] = formatAttribute(e)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case [][]Attribute:
+		values := make([]string, len(v))
+		for i, group := range v {
+			fields := make([]string, len(group))
+			for j, a := range group {
+				fields[j] = fmt.Sprintf("%s: %s", a.Name, formatAttribute(a.Value))
+			}
+			values[i] = "{" + strings.Join(fields, ", ") + "}"
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	return fmt.Sprint(value)
+}
+
+// JSONNode is the structure of a plan or an expression rendered by RenderJSON. Expressions found in
+// the attributes are rendered as nested nodes.
+type JSONNode struct {
+	Node       string         `json:"node"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Children   []*JSONNode    `json:"children,omitempty"`
+}
+
+// RenderJSON returns a plan as indented JSON, see JSONNode
+func RenderJSON(plan QueryPlan) ([]byte, error) {
+	return json.MarshalIndent(planNode(plan), "", "  ")
+}
+
+func planNode(plan QueryPlan) *JSONNode {
+	node := &JSONNode{Node: NodeName(plan), Attributes: attributeMap(plan.Attributes())}
+	for _, child := range plan.Children() {
+		node.Children = append(node.Children, planNode(child))
+	}
+	return node
+}
+
+func expressionNode(e Expression) *JSONNode {
+	node := &JSONNode{Node: NodeName(e), Attributes: attributeMap(e.Attributes())}
+	for _, child := range e.Children() {
+		node.Children = append(node.Children, expressionNode(child))
+	}
+	return node
+}
+
+func attributeMap(attributes []Attribute) map[string]any {
+	if len(attributes) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(attributes))
+	for _, a := range attributes {
+		m[a.Name] = attributeValue(a.Value)
+	}
+	return m
+}
+
+func attributeValue(value any) any {
+	switch v := value.(type) {
+	case Expression:
+		return expressionNode(v)
+	case []Expression:
+		values := make([]any, len(v))
+		for i, e := range v {
+			values[i] = attributeValue(e)
+		}
+		return values
+	case [][]Expression:
+		values := make([]any, len(v))
+		for i, e := range v {
+			values[i] = attributeValue(e)
+		}
+		return values
+	case [][]Attribute:
+		values := make([]any, len(v))
+		for i, group := range v {
+			values[i] = attributeMap(group)
+		}
+		return values
+	}
+	return value
+}
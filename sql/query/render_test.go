@@ -0,0 +1,125 @@
+package query
+
+import (
+	"encoding/json"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func renderPlan(t *testing.T) QueryPlan {
+	t.Helper()
+	_, schema := sortDatabase(t)
+	condition, err := NewBinaryOperation(NewColumnReference(0, types.TypeDecimal), NewConstant(types.NewDecimal("1")), BinaryOperatorGt)
+	if err != nil {
+		t.Fatalf("NewBinaryOperation() = %v, want nil", err)
+	}
+	selection, err := NewSelect(NewLoad("t", schema), condition)
+	if err != nil {
+		t.Fatalf("NewSelect() = %v, want nil", err)
+	}
+	sort, err := NewSort(selection, []SortKey{{Expression: NewColumnReference(1, types.TypeText), Descending: true}})
+	if err != nil {
+		t.Fatalf("NewSort() = %v, want nil", err)
+	}
+	return sort
+}
+
+func TestRenderDOT(t *testing.T) {
+	want := `digraph plan {
+  node [shape=box, fontname="monospace"];
+  n0 [label="Sort\lKeys: [{Expression: ColumnReference(1,text), Descending: true, NullsFirst: false}]\l"];
+  n1 [label="Select\lCondition: BinaryOperation(ColumnReference(0,decimal) gt 1)\l"];
+  n0 -> n1;
+  n2 [label="Load\lTable: t\lSchema: TableSchema(t.a decimal, t.b text)\l"];
+  n1 -> n2;
+}
+`
+	if got := RenderDOT(renderPlan(t)); got != want {
+		t.Errorf("RenderDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	data, err := RenderJSON(renderPlan(t))
+	if err != nil {
+		t.Fatalf("RenderJSON() = %v, want nil", err)
+	}
+	var got any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want nil", err)
+	}
+	var want any
+	err = json.Unmarshal([]byte(`{
+		"node": "Sort",
+		"attributes": {"Keys": [{
+			"Expression": {"node": "ColumnReference", "attributes": {"Index": 1, "Type": "text"}},
+			"Descending": true,
+			"NullsFirst": false
+		}]},
+		"children": [{
+			"node": "Select",
+			"attributes": {"Condition": {
+				"node": "BinaryOperation",
+				"attributes": {"Operator": "gt"},
+				"children": [
+					{"node": "ColumnReference", "attributes": {"Index": 0, "Type": "decimal"}},
+					{"node": "Constant", "attributes": {"Value": "1", "Type": "decimal"}}
+				]
+			}},
+			"children": [{
+				"node": "Load",
+				"attributes": {"Table": "t", "Schema": "TableSchema(t.a decimal, t.b text)"}
+			}]
+		}]
+	}`), &want)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RenderJSON() = %s", data)
+	}
+}
+
+// nameCollector records the names of the nodes it visits, skipping the inputs of the node named skip
+type nameCollector struct {
+	names *[]string
+	skip  string
+}
+
+func (n nameCollector) Visit(plan QueryPlan) Visitor {
+	*n.names = append(*n.names, NodeName(plan))
+	if NodeName(plan) == n.skip {
+		return nil
+	}
+	return n
+}
+
+func (n nameCollector) VisitExpression(e Expression) ExpressionVisitor {
+	*n.names = append(*n.names, NodeName(e))
+	return n
+}
+
+func TestWalk(t *testing.T) {
+	plan := renderPlan(t)
+	cases := []struct {
+		skip string
+		want []string
+	}{
+		{want: []string{"Sort", "Select", "Load"}},
+		{skip: "Select", want: []string{"Sort", "Select"}},
+	}
+	for _, c := range cases {
+		var names []string
+		Walk(nameCollector{names: &names, skip: c.skip}, plan)
+		if !reflect.DeepEqual(names, c.want) {
+			t.Errorf("Walk() skipping %q visited %v, want %v", c.skip, names, c.want)
+		}
+	}
+
+	var names []string
+	WalkExpression(nameCollector{names: &names}, plan.Children()[0].(*Select).Condition)
+	if want := []string{"BinaryOperation", "ColumnReference", "Constant"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("WalkExpression() visited %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"sort"
+)
+
+// SortKey is one expression rows are ordered by, NULLs are placed according to NullsFirst
+// whatever the direction
+type SortKey struct {
+	Expression Expression
+	Descending bool
+	NullsFirst bool
+}
+
+func (k SortKey) String() string {
+	direction := "asc"
+	if k.Descending {
+		direction = "desc"
+	}
+	nulls := "last"
+	if k.NullsFirst {
+		nulls = "first"
+	}
+	return fmt.Sprintf("%s %s nulls %s", k.Expression, direction, nulls)
+}
+
+type Sort struct {
+	From QueryPlan
+	Keys []SortKey
+}
+
+func NewSort(from QueryPlan, keys []SortKey) (*Sort, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("sort requires at least one key")
+	}
+	for _, key := range keys {
+		if err := key.Expression.Check(from.Schema()); err != nil {
+			return nil, err
+		}
+		if !key.Expression.Type().Comparable() {
+			return nil, fmt.Errorf("cannot order by %s: values of type %v are incomparable", key.Expression, key.Expression.Type())
+		}
+	}
+	return &Sort{From: from, Keys: keys}, nil
+}
+
+func (s *Sort) Schema() types.TableSchema {
+	return s.From.Schema()
+}
+
+// Open reads the whole input before returning the first row
+func (s *Sort) Open(ctx context.Context, db *storage.Database) (RowIterator, error) {
+	schema := s.From.Schema()
+	iterator, err := s.From.Open(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	from, err := drain(iterator)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate the keys once per row rather than once per comparison
+	keyed := make([]sortRow, len(from))
+	for i, values := range from {
+		keys, err := evaluateKeys(s.Keys, &types.Row{Schema: schema, Values: values})
+		if err != nil {
+			return nil, err
+		}
+		keyed[i] = sortRow{values: values, keys: keys}
+	}
+	var sortErr error
+	sort.SliceStable(keyed, func(i, j int) bool {
+		c, err := compareKeys(s.Keys, keyed[i].keys, keyed[j].keys)
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return c < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	rows := make([][]types.Value, len(keyed))
+	for i := range keyed {
+		rows[i] = keyed[i].values
+	}
+	return &sliceIterator{ctx: ctx, rows: rows, buffered: true}, nil
+}
+
+func (s *Sort) Run(ctx context.Context, db *storage.Database) (*types.Relation, error) {
+	return Materialize(ctx, s, db)
+}
+
+func (s *Sort) Print(printer *Printer) {
+	printer.Println("Sort {")
+	printer.Indent()
+	printer.Println("From:")
+	s.From.Print(printer)
+	printer.Println("Keys:")
+	printer.Indent()
+	for _, key := range s.Keys {
+		printer.Println("%s", key)
+	}
+	printer.Dedent()
+	printer.Dedent()
+	printer.Println("}")
+}
+
+func (s *Sort) Children() []QueryPlan {
+	return []QueryPlan{s.From}
+}
+
+func (s *Sort) Attributes() []Attribute {
+	return []Attribute{{Name: "Keys", Value: sortKeyAttributes(s.Keys)}}
+}
+
+type sortRow struct {
+	values   []types.Value
+	keys     []types.Value
+	sequence int
+}
+
+func evaluateKeys(keys []SortKey, row *types.Row) ([]types.Value, error) {
+	values := make([]types.Value, len(keys))
+	for i, key := range keys {
+		value, err := key.Expression.Evaluate(row)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// compareKeys orders two rows by their evaluated keys, returning a negative number when a sorts first
+func compareKeys(keys []SortKey, a, b []types.Value) (int, error) {
+	for i, key := range keys {
+		aNull, bNull := types.IsNull(a[i]), types.IsNull(b[i])
+		switch {
+		case aNull && bNull:
+			continue
+		case aNull || bNull:
+			if aNull == key.NullsFirst {
+				return -1, nil
+			}
+			return 1, nil
+		}
+
+		comparison := a[i].Compare(b[i])
+		switch comparison {
+		case types.ComparisonEqual:
+			continue
+		case types.ComparisonLess, types.ComparisonGreater:
+			if key.Descending {
+				return -int(comparison), nil
+			}
+			return int(comparison), nil
+		}
+		return 0, fmt.Errorf("cannot order %v and %v by %s", a[i], b[i], key.Expression)
+	}
+	return 0, nil
+}
@@ -0,0 +1,96 @@
+package query
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/storage"
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"reflect"
+	"testing"
+)
+
+func sortDatabase(t *testing.T) (*storage.Database, types.TableSchema) {
+	t.Helper()
+	schema := types.TableSchema{
+		Columns: []types.ColumnSchema{
+			{Name: "a", Type: types.TypeDecimal, Nullable: true},
+			{Name: "b", Type: types.TypeText},
+		},
+	}
+	db := storage.NewDatabase()
+	table, err := db.CreateTable("t", schema)
+	if err != nil {
+		t.Fatalf("db.CreateTable() = %v, want nil", err)
+	}
+	rows := [][]types.Value{
+		{types.NewDecimal("2"), types.NewText("x")},
+		{types.NewNull(types.TypeDecimal), types.NewText("y")},
+		{types.NewDecimal("1"), types.NewText("z")},
+		{types.NewDecimal("2"), types.NewText("w")},
+	}
+	for _, row := range rows {
+		if err := table.Insert(row); err != nil {
+			t.Fatalf("table.Insert() = %v, want nil", err)
+		}
+	}
+	return db, schema
+}
+
+func TestSort(t *testing.T) {
+	db, schema := sortDatabase(t)
+	a := NewColumnReference(0, types.TypeDecimal)
+	b := NewColumnReference(1, types.TypeText)
+
+	cases := []struct {
+		name string
+		keys []SortKey
+		want []string
+	}{
+		{
+			name: "ascending nulls last",
+			keys: []SortKey{{Expression: a}},
+			want: []string{"z", "x", "w", "y"},
+		},
+		{
+			name: "descending nulls first",
+			keys: []SortKey{{Expression: a, Descending: true, NullsFirst: true}},
+			want: []string{"y", "x", "w", "z"},
+		},
+		{
+			name: "ascending nulls first",
+			keys: []SortKey{{Expression: a, NullsFirst: true}},
+			want: []string{"y", "z", "x", "w"},
+		},
+		{
+			name: "multiple keys",
+			keys: []SortKey{{Expression: a, Descending: true}, {Expression: b}},
+			want: []string{"w", "x", "z", "y"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := NewSort(NewLoad("t", schema), c.keys)
+			if err != nil {
+				t.Fatalf("NewSort() = %v, want nil", err)
+			}
+			got := names(run(t, s, db))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("sorted rows = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNewSortInvalid(t *testing.T) {
+	_, schema := sortDatabase(t)
+	load := NewLoad("t", schema)
+
+	if _, err := NewSort(load, nil); err == nil {
+		t.Errorf("NewSort() without keys returned nil, want error")
+	}
+	if _, err := NewSort(load, []SortKey{{Expression: NewColumnReference(2, types.TypeText)}}); err == nil {
+		t.Errorf("NewSort() with out of range key returned nil, want error")
+	}
+	if _, err := NewSort(load, []SortKey{{Expression: NewColumnReference(0, types.Type(42))}}); err == nil {
+		t.Errorf("NewSort() with incomparable key returned nil, want error")
+	}
+}
@@ -0,0 +1,126 @@
+package query
+
+import (
+	"reflect"
+)
+
+// Attribute is a named property of a plan or an expression. Value is one of
+//   - a string, an int or a bool
+//   - an Expression, a []Expression or a [][]Expression, such as the rows of Values
+//   - a [][]Attribute, a list of groups of named values such as the keys of Sort
+type Attribute struct {
+	Name  string
+	Value any
+}
+
+// Visitor is called by Walk for every node of a plan. Visit returns the visitor used for the
+// inputs of the node, or nil to skip them.
+type Visitor interface {
+	Visit(plan QueryPlan) Visitor
+}
+
+// Walk visits a plan in depth first order, each node before its inputs
+func Walk(v Visitor, plan QueryPlan) {
+	if v = v.Visit(plan); v == nil {
+		return
+	}
+	for _, child := range plan.Children() {
+		Walk(v, child)
+	}
+}
+
+// WithChildren returns a copy of a plan reading from other inputs, given in the order of Children.
+// The inputs must have the same schemas as those they replace.
+func WithChildren(plan QueryPlan, children []QueryPlan) QueryPlan {
+	switch p := plan.(type) {
+	case *Select:
+		c := *p
+		c.From = children[0]
+		return &c
+	case *Project:
+		c := *p
+		c.From = children[0]
+		return &c
+	case *Sort:
+		c := *p
+		c.From = children[0]
+		return &c
+	case *Limit:
+		c := *p
+		c.From = children[0]
+		return &c
+	case *TopN:
+		c := *p
+		c.From = children[0]
+		return &c
+	case *Aggregate:
+		c := *p
+		c.From = children[0]
+		return &c
+	case *Join:
+		c := *p
+		c.Left, c.Right = children[0], children[1]
+		return &c
+	case *HashJoin:
+		c := *p
+		c.Left, c.Right = children[0], children[1]
+		return &c
+	case *MergeJoin:
+		c := *p
+		c.Left, c.Right = children[0], children[1]
+		return &c
+	case *Explain:
+		c := *p
+		c.Plan = children[0]
+		return &c
+	}
+	return plan
+}
+
+// ExpressionVisitor is called by WalkExpression for every node of an expression. VisitExpression
+// returns the visitor used for the operands of the node, or nil to skip them.
+type ExpressionVisitor interface {
+	VisitExpression(e Expression) ExpressionVisitor
+}
+
+// WalkExpression visits an expression in depth first order, each node before its operands
+func WalkExpression(v ExpressionVisitor, e Expression) {
+	if v = v.VisitExpression(e); v == nil {
+		return
+	}
+	for _, child := range e.Children() {
+		WalkExpression(v, child)
+	}
+}
+
+// NodeName returns the name of the type of a plan or an expression, such as "HashJoin"
+func NodeName(node any) string {
+	if analyzed, ok := node.(*analyzedPlan); ok {
+		return NodeName(analyzed.QueryPlan)
+	}
+	t := reflect.TypeOf(node)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func outputColumnAttributes(columns []OutputColumn) [][]Attribute {
+	attributes := make([][]Attribute, len(columns))
+	for i, c := range columns {
+		attributes[i] = []Attribute{{Name: "Name", Value: c.Name}, {Name: "Expression", Value: c.Expression}}
+	}
+	return attributes
+}
+
+func sortKeyAttributes(keys []SortKey) [][]Attribute {
+	attributes := make([][]Attribute, len(keys))
+	for i, key := range keys {
+		attributes[i] = []Attribute{
+			{Name: "Expression", Value: key.Expression},
+			{Name: "Descending", Value: key.Descending},
+			{Name: "NullsFirst", Value: key.NullsFirst},
+		}
+	}
+	return attributes
+}
@@ -34,6 +34,16 @@ func (e ExpressionList) String() string {
 	return fmt.Sprintf("ExpressionList(%s)", strings.Join(list, ", "))
 }
 
+// AliasedExpression represents an entry of the select list renamed with AS
+type AliasedExpression struct {
+	Expression Expression
+	Alias      string
+}
+
+func (a AliasedExpression) String() string {
+	return fmt.Sprintf("Alias(%s, %s)", a.Expression, a.Alias)
+}
+
 // StringLiteral represents a string literal
 type StringLiteral struct {
 	Value string
@@ -63,6 +73,79 @@ func (o BinaryOperation) String() string {
 	return fmt.Sprintf("BinaryOperation(Left: %s, Operator: %s, Right: %s)", o.Left, o.Operator, o.Right)
 }
 
+// ArithmeticOperation represents a binary arithmetic operation with a left and right side
+type ArithmeticOperation struct {
+	Left     Expression
+	Operator lexer.ArithmeticOperator
+	Right    Expression
+}
+
+func (o ArithmeticOperation) String() string {
+	return fmt.Sprintf("ArithmeticOperation(Left: %s, Operator: %s, Right: %s)", o.Left, o.Operator, o.Right)
+}
+
+// Negation represents a unary minus
+type Negation struct {
+	Expression Expression
+}
+
+func (n Negation) String() string {
+	return fmt.Sprintf("Negation(%s)", n.Expression)
+}
+
+// FunctionCall represents a call such as COUNT(*) or SUM(DISTINCT x)
+type FunctionCall struct {
+	Name      string
+	Arguments []Expression
+	// Star is set for COUNT(*), which has no arguments
+	Star     bool
+	Distinct bool
+}
+
+func (f FunctionCall) String() string {
+	if f.Star {
+		return fmt.Sprintf("FunctionCall(%s, *)", f.Name)
+	}
+	args := make([]string, len(f.Arguments))
+	for i, arg := range f.Arguments {
+		args[i] = arg.String()
+	}
+	distinct := ""
+	if f.Distinct {
+		distinct = "Distinct "
+	}
+	return fmt.Sprintf("FunctionCall(%s, %s%s)", f.Name, distinct, strings.Join(args, ", "))
+}
+
+// And represents a logical conjunction of two expressions
+type And struct {
+	Left  Expression
+	Right Expression
+}
+
+func (a And) String() string {
+	return fmt.Sprintf("And(Left: %s, Right: %s)", a.Left, a.Right)
+}
+
+// Or represents a logical disjunction of two expressions
+type Or struct {
+	Left  Expression
+	Right Expression
+}
+
+func (o Or) String() string {
+	return fmt.Sprintf("Or(Left: %s, Right: %s)", o.Left, o.Right)
+}
+
+// Not represents a logical negation of an expression
+type Not struct {
+	Expression Expression
+}
+
+func (n Not) String() string {
+	return fmt.Sprintf("Not(%s)", n.Expression)
+}
+
 // ColumnReference represents a reference to a column in a table
 type ColumnReference struct {
 	Relation string
@@ -96,17 +179,41 @@ func (b Boolean) String() string {
 	return fmt.Sprintf("Boolean(%v)", b.Value)
 }
 
+// Null represents the NULL literal
+type Null struct{}
+
+func (n Null) String() string {
+	return "Null"
+}
+
+// IsNull represents an IS NULL or IS NOT NULL test
+type IsNull struct {
+	Expression Expression
+	Not        bool
+}
+
+func (n IsNull) String() string {
+	if n.Not {
+		return fmt.Sprintf("IsNotNull(%s)", n.Expression)
+	}
+	return fmt.Sprintf("IsNull(%s)", n.Expression)
+}
+
 // TableReference represents a reference to a table
 type TableReference interface {
 	String() string
 }
 
-// TableName represents a reference to a table by name
+// TableName represents a reference to a table by name, Alias is empty unless the table is renamed
 type TableName struct {
-	Name string
+	Name  string
+	Alias string
 }
 
 func (t TableName) String() string {
+	if t.Alias != "" {
+		return fmt.Sprintf("Table(%s, Alias: %s)", t.Name, t.Alias)
+	}
 	return fmt.Sprintf("Table(%s)", t.Name)
 }
 
@@ -127,6 +234,7 @@ const (
 	JoinTypeInner JoinType = iota
 	JoinTypeLeft
 	JoinTypeRight
+	JoinTypeFull
 )
 
 func (j JoinType) String() string {
@@ -137,20 +245,275 @@ func (j JoinType) String() string {
 		return "left outer"
 	case JoinTypeRight:
 		return "right outer"
+	case JoinTypeFull:
+		return "full outer"
 	}
 	return fmt.Sprintf("Unknown JoinType(%d)", j)
 }
 
+// NullsOrder places NULLs before or after every other value in an ordering
+type NullsOrder int
+
+const (
+	// NullsDefault sorts NULLs as if they were larger than every other value
+	NullsDefault NullsOrder = iota
+	NullsFirst
+	NullsLast
+)
+
+func (n NullsOrder) String() string {
+	switch n {
+	case NullsDefault:
+		return "default"
+	case NullsFirst:
+		return "first"
+	case NullsLast:
+		return "last"
+	}
+	return fmt.Sprintf("Unknown NullsOrder(%d)", n)
+}
+
+// OrderingTerm represents a single key of an ORDER BY clause
+type OrderingTerm struct {
+	Expression Expression
+	Descending bool
+	Nulls      NullsOrder
+}
+
+func (o OrderingTerm) String() string {
+	direction := "asc"
+	if o.Descending {
+		direction = "desc"
+	}
+	return fmt.Sprintf("OrderingTerm(%s %s, Nulls: %s)", o.Expression, direction, o.Nulls)
+}
+
 type SelectStatement struct {
-	What  SelectList
-	From  TableReference
-	Where Expression
+	What    SelectList
+	From    TableReference
+	Where   Expression
+	GroupBy []Expression
+	Having  Expression
+	OrderBy []OrderingTerm
+	// Limit and Offset are nil when absent, FETCH FIRST n ROWS ONLY is stored as Limit
+	Limit  Expression
+	Offset Expression
 }
 
 func (s SelectStatement) String() string {
-	where := ""
+	builder := new(strings.Builder)
+	fmt.Fprintf(builder, "SelectStatement(What: %s, From: %s", s.What.String(), s.From.String())
+	if s.Where != nil {
+		fmt.Fprintf(builder, ", Where: %s", s.Where.String())
+	}
+	if len(s.GroupBy) > 0 {
+		fmt.Fprintf(builder, ", GroupBy: %s", ExpressionList{Expressions: s.GroupBy})
+	}
+	if s.Having != nil {
+		fmt.Fprintf(builder, ", Having: %s", s.Having.String())
+	}
+	if len(s.OrderBy) > 0 {
+		terms := make([]string, len(s.OrderBy))
+		for i, term := range s.OrderBy {
+			terms[i] = term.String()
+		}
+		fmt.Fprintf(builder, ", OrderBy: %s", strings.Join(terms, ", "))
+	}
+	if s.Limit != nil {
+		fmt.Fprintf(builder, ", Limit: %s", s.Limit.String())
+	}
+	if s.Offset != nil {
+		fmt.Fprintf(builder, ", Offset: %s", s.Offset.String())
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// InsertStatement represents INSERT INTO table [(columns)] followed by either VALUES rows or a SELECT query
+type InsertStatement struct {
+	Table string
+	// Columns is nil when the values are given for every column of the table in order
+	Columns []string
+	Values  [][]Expression
+	Query   *SelectStatement
+}
+
+func (s InsertStatement) String() string {
+	builder := new(strings.Builder)
+	fmt.Fprintf(builder, "InsertStatement(Table: %s", s.Table)
+	if s.Columns != nil {
+		fmt.Fprintf(builder, ", Columns: %s", strings.Join(s.Columns, ", "))
+	}
+	if s.Query != nil {
+		fmt.Fprintf(builder, ", Query: %s", s.Query)
+	} else {
+		rows := make([]string, len(s.Values))
+		for i, row := range s.Values {
+			rows[i] = ExpressionList{Expressions: row}.String()
+		}
+		fmt.Fprintf(builder, ", Values: %s", strings.Join(rows, ", "))
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// Assignment represents column = value in the SET clause of an UPDATE
+type Assignment struct {
+	Column string
+	Value  Expression
+}
+
+func (a Assignment) String() string {
+	return fmt.Sprintf("Assignment(%s, %s)", a.Column, a.Value)
+}
+
+// UpdateStatement represents UPDATE table SET column = value, ... [WHERE condition]
+type UpdateStatement struct {
+	Table       string
+	Assignments []Assignment
+	// Where is nil when every row is updated
+	Where Expression
+}
+
+func (s UpdateStatement) String() string {
+	assignments := make([]string, len(s.Assignments))
+	for i, a := range s.Assignments {
+		assignments[i] = a.String()
+	}
+	if s.Where != nil {
+		return fmt.Sprintf("UpdateStatement(Table: %s, Set: %s, Where: %s)", s.Table, strings.Join(assignments, ", "), s.Where)
+	}
+	return fmt.Sprintf("UpdateStatement(Table: %s, Set: %s)", s.Table, strings.Join(assignments, ", "))
+}
+
+// DeleteStatement represents DELETE FROM table [WHERE condition]
+type DeleteStatement struct {
+	Table string
+	// Where is nil when every row is deleted
+	Where Expression
+}
+
+func (s DeleteStatement) String() string {
 	if s.Where != nil {
-		where = fmt.Sprintf(", Where: %s", s.Where.String())
+		return fmt.Sprintf("DeleteStatement(Table: %s, Where: %s)", s.Table, s.Where)
 	}
-	return fmt.Sprintf("SelectStatement(What: %s, From: %s, Where:%s)", s.What.String(), s.From.String(), where)
+	return fmt.Sprintf("DeleteStatement(Table: %s)", s.Table)
+}
+
+// ColumnDefinition represents a column of CREATE TABLE, Type is the type name as written in the statement
+type ColumnDefinition struct {
+	Name    string
+	Type    string
+	NotNull bool
+	// Default is nil when the column has no default value
+	Default Expression
+}
+
+func (c ColumnDefinition) String() string {
+	builder := new(strings.Builder)
+	fmt.Fprintf(builder, "Column(%s %s", c.Name, c.Type)
+	if c.NotNull {
+		builder.WriteString(" NotNull")
+	}
+	if c.Default != nil {
+		fmt.Fprintf(builder, " Default: %s", c.Default)
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// CreateTableStatement represents CREATE TABLE [IF NOT EXISTS] name (columns)
+type CreateTableStatement struct {
+	Name        string
+	IfNotExists bool
+	Columns     []ColumnDefinition
+}
+
+func (s CreateTableStatement) String() string {
+	columns := make([]string, len(s.Columns))
+	for i, c := range s.Columns {
+		columns[i] = c.String()
+	}
+	return fmt.Sprintf("CreateTableStatement(Name: %s, IfNotExists: %t, Columns: %s)", s.Name, s.IfNotExists, strings.Join(columns, ", "))
+}
+
+// DropTableStatement represents DROP TABLE [IF EXISTS] name
+type DropTableStatement struct {
+	Name     string
+	IfExists bool
+}
+
+func (s DropTableStatement) String() string {
+	return fmt.Sprintf("DropTableStatement(Name: %s, IfExists: %t)", s.Name, s.IfExists)
+}
+
+// AlterTableStatement represents ALTER TABLE name followed by a single change to the table
+type AlterTableStatement struct {
+	Table  string
+	Action AlterTableAction
+}
+
+func (s AlterTableStatement) String() string {
+	return fmt.Sprintf("AlterTableStatement(Table: %s, Action: %s)", s.Table, s.Action)
+}
+
+// AlterTableAction is one of AddColumn, DropColumn, RenameColumn and RenameTable
+type AlterTableAction interface {
+	String() string
+}
+
+// AddColumn represents ADD [COLUMN] definition
+type AddColumn struct {
+	Column ColumnDefinition
+}
+
+func (a AddColumn) String() string {
+	return fmt.Sprintf("AddColumn(%s)", a.Column)
+}
+
+// DropColumn represents DROP [COLUMN] name
+type DropColumn struct {
+	Name string
+}
+
+func (d DropColumn) String() string {
+	return fmt.Sprintf("DropColumn(%s)", d.Name)
+}
+
+// RenameColumn represents RENAME [COLUMN] from TO to
+type RenameColumn struct {
+	From string
+	To   string
+}
+
+func (r RenameColumn) String() string {
+	return fmt.Sprintf("RenameColumn(%s, %s)", r.From, r.To)
+}
+
+// RenameTable represents RENAME TO name
+type RenameTable struct {
+	To string
+}
+
+func (r RenameTable) String() string {
+	return fmt.Sprintf("RenameTable(%s)", r.To)
+}
+
+// ExplainStatement represents EXPLAIN [ANALYZE] query
+type ExplainStatement struct {
+	Query   *SelectStatement
+	Analyze bool
+}
+
+func (s ExplainStatement) String() string {
+	return fmt.Sprintf("ExplainStatement(Query: %s, Analyze: %t)", s.Query, s.Analyze)
+}
+
+// AnalyzeStatement represents ANALYZE [table], Table is empty to analyze every table
+type AnalyzeStatement struct {
+	Table string
+}
+
+func (s AnalyzeStatement) String() string {
+	return fmt.Sprintf("AnalyzeStatement(Table: %s)", s.Table)
 }
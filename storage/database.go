@@ -3,19 +3,27 @@ package storage
 import (
 	"fmt"
 	"github.com/Vignesh-Rajarajan/go-db/types"
+	"sort"
 )
 
 type Database struct {
 	tables map[string]*types.Relation
+	// statistics are kept by table name until the table is analyzed again or dropped
+	statistics map[string]*TableStatistics
 }
 
 func NewDatabase() *Database {
 	return &Database{
-		tables: make(map[string]*types.Relation),
+		tables:     make(map[string]*types.Relation),
+		statistics: make(map[string]*TableStatistics),
 	}
 }
 
+// GetTable returns a table, the statistics tables are built from the current statistics on every call
 func (db *Database) GetTable(name string) (*types.Relation, error) {
+	if IsStatisticsTable(name) {
+		return db.statisticsTable(name), nil
+	}
 	t, ok := db.tables[name]
 	if !ok {
 		return nil, fmt.Errorf("table %s not found", name)
@@ -25,7 +33,7 @@ func (db *Database) GetTable(name string) (*types.Relation, error) {
 
 func (db *Database) CreateTable(name string, schema types.TableSchema) (*types.Relation, error) {
 	_, ok := db.tables[name]
-	if ok {
+	if ok || IsStatisticsTable(name) {
 		return nil, fmt.Errorf("table %s already exists", name)
 	}
 	table := &types.Relation{
@@ -34,3 +42,65 @@ func (db *Database) CreateTable(name string, schema types.TableSchema) (*types.R
 	db.tables[name] = table
 	return table, nil
 }
+
+func (db *Database) DropTable(name string) error {
+	if IsStatisticsTable(name) {
+		return fmt.Errorf("table %s is read-only", name)
+	}
+	_, ok := db.tables[name]
+	if !ok {
+		return fmt.Errorf("table %s not found", name)
+	}
+	delete(db.tables, name)
+	delete(db.statistics, name)
+	return nil
+}
+
+func (db *Database) RenameTable(from, to string) error {
+	if IsStatisticsTable(from) {
+		return fmt.Errorf("table %s is read-only", from)
+	}
+	table, ok := db.tables[from]
+	if !ok {
+		return fmt.Errorf("table %s not found", from)
+	}
+	if _, ok := db.tables[to]; ok || IsStatisticsTable(to) {
+		return fmt.Errorf("table %s already exists", to)
+	}
+	delete(db.tables, from)
+	db.tables[to] = table
+	if statistics, ok := db.statistics[from]; ok {
+		delete(db.statistics, from)
+		db.statistics[to] = statistics
+	}
+	return nil
+}
+
+// TableNames returns the names of the tables in alphabetical order, without the statistics tables
+func (db *Database) TableNames() []string {
+	names := make([]string, 0, len(db.tables))
+	for name := range db.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetStatistics returns the statistics of a table, if it has been analyzed
+func (db *Database) GetStatistics(name string) (*TableStatistics, bool) {
+	statistics, ok := db.statistics[name]
+	return statistics, ok
+}
+
+func (db *Database) SetStatistics(name string, statistics *TableStatistics) error {
+	if _, ok := db.tables[name]; !ok {
+		return fmt.Errorf("table %s not found", name)
+	}
+	db.statistics[name] = statistics
+	return nil
+}
+
+// DeleteStatistics forgets the statistics of a table, for instance once its columns changed
+func (db *Database) DeleteStatistics(name string) {
+	delete(db.statistics, name)
+}
@@ -63,6 +63,7 @@ func GetSampleData() *SampleData {
 			"films":  films,
 			"people": people,
 		},
+		statistics: make(map[string]*TableStatistics),
 	}
 
 	return &SampleData{
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"github.com/Vignesh-Rajarajan/go-db/types"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// HistogramBuckets is the largest number of buckets of the histogram of a column
+const HistogramBuckets = 10
+
+// TableStatistics describes the rows of a table as of the last time it was analyzed
+type TableStatistics struct {
+	RowCount int
+	Columns  []ColumnStatistics
+}
+
+// ColumnStatistics describes the values of a column. Min, Max and Histogram only account for the values
+// other than NULL, they are nil and empty when the column only holds NULLs.
+type ColumnStatistics struct {
+	Name         string
+	NullFraction float64
+	// DistinctCount is the number of distinct values other than NULL
+	DistinctCount int
+	Min           types.Value
+	Max           types.Value
+	Histogram     []Bucket
+}
+
+// Bucket is a range of an equi-depth histogram, in which every bucket holds about the same number of values.
+// All the values equal to the upper bound of a bucket are counted in it, so a value never spans two buckets.
+type Bucket struct {
+	Lower         types.Value
+	Upper         types.Value
+	Count         int
+	DistinctCount int
+}
+
+// ComputeStatistics reads every row of a table to describe its columns
+func ComputeStatistics(table *types.Relation) *TableStatistics {
+	statistics := &TableStatistics{RowCount: len(table.Rows)}
+	for i, column := range table.Schema.Columns {
+		var values []types.Value
+		for _, row := range table.Rows {
+			if !types.IsNull(row[i]) {
+				values = append(values, row[i])
+			}
+		}
+		statistics.Columns = append(statistics.Columns, computeColumnStatistics(column.Name, values, len(table.Rows)))
+	}
+	return statistics
+}
+
+func computeColumnStatistics(name string, values []types.Value, rows int) ColumnStatistics {
+	statistics := ColumnStatistics{Name: name}
+	if rows > 0 {
+		statistics.NullFraction = float64(rows-len(values)) / float64(rows)
+	}
+	if len(values) == 0 {
+		return statistics
+	}
+	slices.SortFunc(values, func(a, b types.Value) int {
+		return int(a.Compare(b))
+	})
+	statistics.Min, statistics.Max = values[0], values[len(values)-1]
+	statistics.DistinctCount = distinctCount(values)
+
+	buckets := min(HistogramBuckets, len(values))
+	for start, i := 0, 1; start < len(values); i++ {
+		end := max(i*len(values)/buckets, start+1)
+		for end < len(values) && values[end].Compare(values[end-1]) == types.ComparisonEqual {
+			end++
+		}
+		statistics.Histogram = append(statistics.Histogram, Bucket{
+			Lower:         values[start],
+			Upper:         values[end-1],
+			Count:         end - start,
+			DistinctCount: distinctCount(values[start:end]),
+		})
+		start = end
+	}
+	return statistics
+}
+
+// distinctCount counts the distinct values of a sorted slice
+func distinctCount(values []types.Value) int {
+	count := 0
+	for i, v := range values {
+		if i == 0 || v.Compare(values[i-1]) != types.ComparisonEqual {
+			count++
+		}
+	}
+	return count
+}
+
+// The statistics can be read from the following tables, which cannot be changed by statements
+const (
+	// TableStatisticsTable has a row per analyzed table with its number of rows
+	TableStatisticsTable = "table_statistics"
+	// ColumnStatisticsTable has a row per column of an analyzed table
+	ColumnStatisticsTable = "column_statistics"
+	// HistogramsTable has a row per bucket of the histogram of every column of an analyzed table
+	HistogramsTable = "column_histograms"
+)
+
+// IsStatisticsTable reports whether a table name is one of the tables listing the statistics
+func IsStatisticsTable(name string) bool {
+	return name == TableStatisticsTable || name == ColumnStatisticsTable || name == HistogramsTable
+}
+
+// statisticsTable lists the statistics of every analyzed table, ordered by table name and column position.
+// Values of the columns are shown as text, as the columns may be of any type, see formatValue.
+func (db *Database) statisticsTable(name string) *types.Relation {
+	text := func(v types.Value) types.Value {
+		if v == nil {
+			return types.NewNull(types.TypeText)
+		}
+		return types.NewText(formatValue(v))
+	}
+	number := func(n int) types.Value {
+		return types.NewDecimal(strconv.Itoa(n))
+	}
+	table := &types.Relation{}
+	switch name {
+	case TableStatisticsTable:
+		table.Schema = types.TableSchema{Columns: []types.ColumnSchema{
+			{Name: "table_name", Type: types.TypeText},
+			{Name: "row_count", Type: types.TypeDecimal},
+		}}
+	case ColumnStatisticsTable:
+		table.Schema = types.TableSchema{Columns: []types.ColumnSchema{
+			{Name: "table_name", Type: types.TypeText},
+			{Name: "column_name", Type: types.TypeText},
+			{Name: "null_fraction", Type: types.TypeDecimal},
+			{Name: "distinct_count", Type: types.TypeDecimal},
+			{Name: "min_value", Type: types.TypeText, Nullable: true},
+			{Name: "max_value", Type: types.TypeText, Nullable: true},
+		}}
+	case HistogramsTable:
+		table.Schema = types.TableSchema{Columns: []types.ColumnSchema{
+			{Name: "table_name", Type: types.TypeText},
+			{Name: "column_name", Type: types.TypeText},
+			{Name: "bucket", Type: types.TypeDecimal},
+			{Name: "lower_bound", Type: types.TypeText},
+			{Name: "upper_bound", Type: types.TypeText},
+			{Name: "row_count", Type: types.TypeDecimal},
+			{Name: "distinct_count", Type: types.TypeDecimal},
+		}}
+	}
+
+	for _, tableName := range db.TableNames() {
+		statistics, ok := db.statistics[tableName]
+		if !ok {
+			continue
+		}
+		if name == TableStatisticsTable {
+			table.Rows = append(table.Rows, []types.Value{types.NewText(tableName), number(statistics.RowCount)})
+			continue
+		}
+		for _, column := range statistics.Columns {
+			if name == ColumnStatisticsTable {
+				// four decimal places are enough to compare fractions
+				fraction := strconv.FormatFloat(math.Round(column.NullFraction*1e4)/1e4, 'f', -1, 64)
+				table.Rows = append(table.Rows, []types.Value{
+					types.NewText(tableName),
+					types.NewText(column.Name),
+					types.NewDecimal(fraction),
+					number(column.DistinctCount),
+					text(column.Min),
+					text(column.Max),
+				})
+				continue
+			}
+			for i, bucket := range column.Histogram {
+				table.Rows = append(table.Rows, []types.Value{
+					types.NewText(tableName),
+					types.NewText(column.Name),
+					number(i + 1),
+					text(bucket.Lower),
+					text(bucket.Upper),
+					number(bucket.Count),
+					number(bucket.DistinctCount),
+				})
+			}
+		}
+	}
+	return table
+}
+
+// formatValue writes a value as it is written in a statement, without the quotes of a string literal,
+// so that it can be compared with the text of a column or a date literal
+func formatValue(v types.Value) string {
+	switch v := v.(type) {
+	case types.Text:
+		return v.Value()
+	case types.Date:
+		return v.Format()
+	case types.Boolean:
+		return strconv.FormatBool(v.Bool())
+	}
+	return v.String()
+}
@@ -0,0 +1,197 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RoundingMode decides how a result is rounded when it has more fractional digits than requested
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds ties to the nearest even digit, also known as banker's rounding
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds ties away from zero
+	RoundHalfUp
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfEven:
+		return "half even"
+	case RoundHalfUp:
+		return "half up"
+	}
+	return fmt.Sprintf("Unknown RoundingMode(%d)", m)
+}
+
+// DefaultDivisionDigits is the number of fractional digits kept by a division when none is specified
+const DefaultDivisionDigits = 16
+
+var bigTen = big.NewInt(10)
+
+// Add returns d + next, exactly
+func (d Decimal) Add(next Decimal) Decimal {
+	a, b, frac := d.aligned(next)
+	return fromUnscaled(a.Add(a, b), frac)
+}
+
+// Sub returns d - next, exactly
+func (d Decimal) Sub(next Decimal) Decimal {
+	return d.Add(next.Neg())
+}
+
+// Mul returns d * next, exactly
+func (d Decimal) Mul(next Decimal) Decimal {
+	a, fracA := d.unscaled()
+	b, fracB := next.unscaled()
+	return fromUnscaled(a.Mul(a, b), fracA+fracB)
+}
+
+// Div returns d / next with at most the given number of fractional digits, rounded with the given mode
+func (d Decimal) Div(next Decimal, digits int, mode RoundingMode) (Decimal, error) {
+	if next.IsZero() {
+		return Decimal{}, fmt.Errorf("division by zero")
+	}
+	if digits < 0 {
+		return Decimal{}, fmt.Errorf("invalid number of fractional digits: %d", digits)
+	}
+	a, fracA := d.unscaled()
+	b, fracB := next.unscaled()
+
+	// a/10^fracA / (b/10^fracB) == (a * 10^(digits+fracB-fracA) / b) / 10^digits
+	shift := digits + fracB - fracA
+	if shift >= 0 {
+		a.Mul(a, pow10(shift))
+	} else {
+		b.Mul(b, pow10(-shift))
+	}
+	quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+	roundQuotient(quotient, remainder, b, mode)
+	return fromUnscaled(quotient, digits), nil
+}
+
+// Mod returns the remainder of d / next, which has the sign of d as in SQL
+func (d Decimal) Mod(next Decimal) (Decimal, error) {
+	if next.IsZero() {
+		return Decimal{}, fmt.Errorf("division by zero")
+	}
+	a, b, frac := d.aligned(next)
+	return fromUnscaled(a.Rem(a, b), frac), nil
+}
+
+// Round returns d with at most the given number of fractional digits, rounded with the given mode
+func (d Decimal) Round(digits int, mode RoundingMode) Decimal {
+	a, frac := d.unscaled()
+	if frac <= digits {
+		return d
+	}
+	divisor := pow10(frac - digits)
+	quotient, remainder := new(big.Int).QuoRem(a, divisor, new(big.Int))
+	roundQuotient(quotient, remainder, divisor, mode)
+	return fromUnscaled(quotient, digits)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	if d.IsZero() {
+		return d
+	}
+	return Decimal{negative: !d.negative, digits: d.digits, scale: d.scale}
+}
+
+// Int returns the decimal as an int, ok is false when it has a fractional part or does not fit
+func (d Decimal) Int() (value int, ok bool) {
+	coefficient, frac := d.unscaled()
+	if frac > 0 || !coefficient.IsInt64() {
+		return 0, false
+	}
+	n := coefficient.Int64()
+	if int64(int(n)) != n {
+		return 0, false
+	}
+	return int(n), true
+}
+
+func (d Decimal) IsZero() bool {
+	return len(d.digits) == 0
+}
+
+// roundQuotient adjusts a truncated quotient by one unit away from zero when the remainder calls for it
+func roundQuotient(quotient, remainder, divisor *big.Int, mode RoundingMode) {
+	if remainder.Sign() == 0 {
+		return
+	}
+	twice := new(big.Int).Abs(remainder)
+	twice.Mul(twice, big.NewInt(2))
+	cmp := twice.Cmp(new(big.Int).Abs(divisor))
+
+	up := cmp > 0
+	if cmp == 0 {
+		switch mode {
+		case RoundHalfUp:
+			up = true
+		case RoundHalfEven:
+			up = quotient.Bit(0) == 1
+		}
+	}
+	if !up {
+		return
+	}
+	// the quotient is truncated towards zero, so rounding up moves it away from zero
+	if (remainder.Sign() < 0) != (divisor.Sign() < 0) {
+		quotient.Sub(quotient, big.NewInt(1))
+	} else {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+}
+
+// unscaled returns the decimal as an integer coefficient and the number of fractional digits,
+// so that the value is coefficient / 10^frac
+func (d Decimal) unscaled() (*big.Int, int) {
+	coefficient := new(big.Int)
+	for _, digit := range d.digits {
+		coefficient.Mul(coefficient, bigTen)
+		coefficient.Add(coefficient, big.NewInt(int64(digit)))
+	}
+	frac := len(d.digits) - d.scale
+	if frac < 0 {
+		coefficient.Mul(coefficient, pow10(-frac))
+		frac = 0
+	}
+	if d.negative {
+		coefficient.Neg(coefficient)
+	}
+	return coefficient, frac
+}
+
+// aligned returns the coefficients of both decimals scaled to the same number of fractional digits
+func (d Decimal) aligned(next Decimal) (*big.Int, *big.Int, int) {
+	a, fracA := d.unscaled()
+	b, fracB := next.unscaled()
+	switch {
+	case fracA < fracB:
+		a.Mul(a, pow10(fracB-fracA))
+		return a, b, fracB
+	case fracA > fracB:
+		b.Mul(b, pow10(fracA-fracB))
+	}
+	return a, b, fracA
+}
+
+func fromUnscaled(coefficient *big.Int, frac int) Decimal {
+	negative := coefficient.Sign() < 0
+	text := new(big.Int).Abs(coefficient).String()
+	digits := make([]uint8, 0, len(text)+frac)
+	for i := len(text); i <= frac; i++ {
+		digits = append(digits, 0)
+	}
+	for _, r := range text {
+		digits = append(digits, uint8(r-'0'))
+	}
+	return normalise(negative, digits, len(digits)-frac)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,125 @@
+package types
+
+import "testing"
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	cases := []struct {
+		a, b                     string
+		sum, difference, product string
+	}{
+		{a: "1", b: "2", sum: "3", difference: "-1", product: "2"},
+		{a: "0.1", b: "0.2", sum: "0.3", difference: "-0.1", product: "0.02"},
+		{a: "123.456", b: "-0.456", sum: "123", difference: "123.912", product: "-56.295936"},
+		{a: "-1.5", b: "-2.25", sum: "-3.75", difference: "0.75", product: "3.375"},
+		{a: "100", b: "0.001", sum: "100.001", difference: "99.999", product: "0.1"},
+		{a: "0", b: "5", sum: "5", difference: "-5", product: "0"},
+		{a: "99999999999999999999", b: "1", sum: "100000000000000000000", difference: "99999999999999999998", product: "99999999999999999999"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+","+c.b, func(t *testing.T) {
+			a, b := NewDecimal(c.a), NewDecimal(c.b)
+			if got := a.Add(b); got.String() != c.sum {
+				t.Errorf("%s + %s = %v, want %s", c.a, c.b, got, c.sum)
+			}
+			if got := a.Sub(b); got.String() != c.difference {
+				t.Errorf("%s - %s = %v, want %s", c.a, c.b, got, c.difference)
+			}
+			if got := a.Mul(b); got.String() != c.product {
+				t.Errorf("%s * %s = %v, want %s", c.a, c.b, got, c.product)
+			}
+		})
+	}
+}
+
+func TestDecimal_Div(t *testing.T) {
+	cases := []struct {
+		a, b   string
+		digits int
+		mode   RoundingMode
+		want   string
+	}{
+		{a: "1", b: "3", digits: 4, mode: RoundHalfEven, want: "0.3333"},
+		{a: "2", b: "3", digits: 4, mode: RoundHalfEven, want: "0.6667"},
+		{a: "-2", b: "3", digits: 4, mode: RoundHalfUp, want: "-0.6667"},
+		{a: "10", b: "4", digits: 0, mode: RoundHalfEven, want: "2"},
+		{a: "10", b: "4", digits: 0, mode: RoundHalfUp, want: "3"},
+		{a: "-10", b: "4", digits: 0, mode: RoundHalfUp, want: "-3"},
+		{a: "14", b: "4", digits: 0, mode: RoundHalfEven, want: "4"},
+		{a: "1.5", b: "0.5", digits: 2, mode: RoundHalfEven, want: "3"},
+		{a: "0.001", b: "8", digits: 5, mode: RoundHalfEven, want: "0.00012"},
+		{a: "0.001", b: "8", digits: 5, mode: RoundHalfUp, want: "0.00013"},
+		{a: "123.45", b: "-0.1", digits: 2, mode: RoundHalfEven, want: "-1234.5"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+"/"+c.b, func(t *testing.T) {
+			got, err := NewDecimal(c.a).Div(NewDecimal(c.b), c.digits, c.mode)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != c.want {
+				t.Errorf("%s / %s with %d digits (%v) = %v, want %s", c.a, c.b, c.digits, c.mode, got, c.want)
+			}
+		})
+	}
+
+	if _, err := NewDecimal("1").Div(DecimalZero(), 2, RoundHalfEven); err == nil {
+		t.Errorf("division by zero returned nil, want error")
+	}
+}
+
+func TestDecimal_Mod(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want string
+	}{
+		{a: "10", b: "3", want: "1"},
+		{a: "-10", b: "3", want: "-1"},
+		{a: "10", b: "-3", want: "1"},
+		{a: "5.5", b: "2", want: "1.5"},
+		{a: "1", b: "0.3", want: "0.1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+"%"+c.b, func(t *testing.T) {
+			got, err := NewDecimal(c.a).Mod(NewDecimal(c.b))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != c.want {
+				t.Errorf("%s %% %s = %v, want %s", c.a, c.b, got, c.want)
+			}
+		})
+	}
+
+	if _, err := NewDecimal("1").Mod(DecimalZero()); err == nil {
+		t.Errorf("modulo by zero returned nil, want error")
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	cases := []struct {
+		input  string
+		digits int
+		mode   RoundingMode
+		want   string
+	}{
+		{input: "2.5", digits: 0, mode: RoundHalfEven, want: "2"},
+		{input: "3.5", digits: 0, mode: RoundHalfEven, want: "4"},
+		{input: "2.5", digits: 0, mode: RoundHalfUp, want: "3"},
+		{input: "-2.5", digits: 0, mode: RoundHalfUp, want: "-3"},
+		{input: "1.2345", digits: 2, mode: RoundHalfEven, want: "1.23"},
+		{input: "1.2", digits: 2, mode: RoundHalfEven, want: "1.2"},
+		{input: "0.004", digits: 2, mode: RoundHalfUp, want: "0"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			got := NewDecimal(c.input).Round(c.digits, c.mode)
+			if got.String() != c.want {
+				t.Errorf("%s rounded to %d digits (%v) = %v, want %s", c.input, c.digits, c.mode, got, c.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ func (b Boolean) Bool() bool {
 }
 
 func (b Boolean) Compare(next Value) Comparison {
+	if IsNull(next) {
+		return ComparisonUnknown
+	}
 	nextBoolean, ok := next.(Boolean)
 	if !ok {
 		return ComparisonIncomparable
@@ -32,6 +35,13 @@ func (b Boolean) Compare(next Value) Comparison {
 	return ComparisonLess
 }
 
+func (b Boolean) Hash() uint64 {
+	if b.value {
+		return hashOf(TypeBoolean, []byte{1})
+	}
+	return hashOf(TypeBoolean, []byte{0})
+}
+
 func (b Boolean) String() string {
 	return fmt.Sprintf("Boolean(%v)", b.value)
 }
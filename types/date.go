@@ -1,6 +1,10 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 type Date struct {
 	year, month, day int
@@ -13,6 +17,27 @@ func NewDate(year, month, day int) Date {
 	return Date{year: year, month: month, day: day}
 }
 
+// ParseDate parses a date in the ISO 8601 format YYYY-MM-DD.
+func ParseDate(input string) (Date, error) {
+	parts := strings.Split(input, "-")
+	if len(parts) != 3 || len(parts[0]) != 4 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return Date{}, fmt.Errorf("invalid date format: %q, expected YYYY-MM-DD", input)
+	}
+	var fields [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Date{}, fmt.Errorf("invalid date format: %q, expected YYYY-MM-DD", input)
+		}
+		fields[i] = n
+	}
+	year, month, day := fields[0], fields[1], fields[2]
+	if year < 1 || month < 1 || month > 12 || day < 1 || day > daysInMonth(year, month) {
+		return Date{}, fmt.Errorf("date out of range: %q", input)
+	}
+	return Date{year: year, month: month, day: day}, nil
+}
+
 func daysInMonth(year int, month int) int {
 	switch month {
 	case 1, 3, 5, 7, 8, 10, 12:
@@ -35,6 +60,9 @@ func (d Date) Type() Type {
 }
 
 func (d Date) Compare(v Value) Comparison {
+	if IsNull(v) {
+		return ComparisonUnknown
+	}
 	next, ok := v.(Date)
 	if !ok {
 		return ComparisonIncomparable
@@ -57,6 +85,15 @@ func (d Date) Compare(v Value) Comparison {
 	return ComparisonEqual
 }
 
+func (d Date) Hash() uint64 {
+	return hashOf(TypeDate, []byte{byte(d.year >> 8), byte(d.year), byte(d.month), byte(d.day)})
+}
+
+// Format returns the date in the ISO 8601 format YYYY-MM-DD read by ParseDate
+func (d Date) Format() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day)
+}
+
 func (d Date) String() string {
 	return fmt.Sprintf("Date(%4d-%2d-%2d)", d.year, d.month, d.day)
 }
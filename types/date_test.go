@@ -32,6 +32,56 @@ func TestNewDate(t *testing.T) {
 	}
 }
 
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    Date
+		wantErr bool
+	}{
+		{
+			input: "1994-09-23",
+			want:  Date{year: 1994, month: 9, day: 23},
+		},
+		{
+			input: "2000-02-29",
+			want:  Date{year: 2000, month: 2, day: 29},
+		},
+		{
+			input:   "1999-02-29",
+			wantErr: true,
+		},
+		{
+			input:   "1994-9-23",
+			wantErr: true,
+		},
+		{
+			input:   "hello",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			got, err := ParseDate(c.input)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) returned nil, want error", c.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDate(%q) returned error, want nil: %v", c.input, err)
+			}
+			if got != c.want {
+				t.Errorf("ParseDate(%q) == %v, want %v", c.input, got, c.want)
+			}
+			if formatted := got.Format(); formatted != c.input {
+				t.Errorf("Format() == %q, want %q", formatted, c.input)
+			}
+		})
+	}
+}
+
 func TestDaysInMonth(t *testing.T) {
 	cases := []struct {
 		year  int
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strings"
 )
@@ -62,6 +63,9 @@ func (d Decimal) Type() Type {
 }
 
 func (d Decimal) Compare(next Value) Comparison {
+	if IsNull(next) {
+		return ComparisonUnknown
+	}
 	nextDecimal, ok := next.(Decimal)
 	if !ok {
 		return ComparisonIncomparable
@@ -107,6 +111,19 @@ func (d Decimal) Compare(next Value) Comparison {
 	return ComparisonEqual
 }
 
+// Hash relies on decimals being normalised, so that equal values such as 1 and 1.0 have the same digits and scale
+func (d Decimal) Hash() uint64 {
+	if d.IsZero() {
+		return hashOf(TypeDecimal, nil)
+	}
+	data := make([]byte, 0, len(d.digits)+9)
+	if d.negative {
+		data = append(data, '-')
+	}
+	data = binary.BigEndian.AppendUint64(data, uint64(d.scale))
+	return hashOf(TypeDecimal, append(data, d.digits...))
+}
+
 func (d Decimal) String() string {
 	builder := new(strings.Builder)
 	if d.negative {
@@ -0,0 +1,34 @@
+package types
+
+// Null is the SQL NULL value. It keeps the type of the column it stands in for, so that
+// a relation padded by an outer join still satisfies its schema.
+type Null struct {
+	T Type
+}
+
+func NewNull(t Type) Null {
+	return Null{T: t}
+}
+
+func (n Null) Type() Type {
+	return n.T
+}
+
+func (n Null) Compare(next Value) Comparison {
+	return ComparisonUnknown
+}
+
+// Hash is only defined so that Null is a Value, NULL never compares as equal to anything
+func (n Null) Hash() uint64 {
+	return hashOf(n.T, nil)
+}
+
+func (n Null) String() string {
+	return "NULL"
+}
+
+// IsNull reports whether the value is a SQL NULL
+func IsNull(v Value) bool {
+	_, ok := v.(Null)
+	return ok
+}
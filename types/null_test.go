@@ -0,0 +1,65 @@
+package types
+
+import "testing"
+
+func TestNull_Compare(t *testing.T) {
+	values := []Value{
+		NewBoolean(true),
+		NewDate(2020, 1, 1),
+		NewDecimal("1"),
+		NewText("hello"),
+		NewNull(TypeText),
+	}
+
+	for _, v := range values {
+		if got := v.Compare(NewNull(v.Type())); got != ComparisonUnknown {
+			t.Errorf("%v.Compare(NULL) == %d, want %d", v, got, ComparisonUnknown)
+		}
+		if got := NewNull(v.Type()).Compare(v); got != ComparisonUnknown {
+			t.Errorf("NULL.Compare(%v) == %d, want %d", v, got, ComparisonUnknown)
+		}
+	}
+}
+
+func TestColumnSchema_CheckNull(t *testing.T) {
+	cases := []struct {
+		column  ColumnSchema
+		value   Value
+		wantErr bool
+	}{
+		{
+			column: ColumnSchema{Name: "name", Type: TypeText, Nullable: true},
+			value:  NewNull(TypeText),
+		},
+		{
+			column: ColumnSchema{Name: "name", Type: TypeText, Nullable: true},
+			value:  NewNull(TypeNull),
+		},
+		{
+			column:  ColumnSchema{Name: "name", Type: TypeText, Nullable: true},
+			value:   NewNull(TypeDate),
+			wantErr: true,
+		},
+		{
+			column:  ColumnSchema{Name: "name", Type: TypeText},
+			value:   NewNull(TypeText),
+			wantErr: true,
+		},
+		{
+			column: ColumnSchema{Name: "name", Type: TypeText},
+			value:  NewText("hello"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.column.String(), func(t *testing.T) {
+			err := c.column.Check(c.value)
+			if c.wantErr && err == nil {
+				t.Fatalf("Check(%v) returned nil, want error", c.value)
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("Check(%v) returned error, want nil: %v", c.value, err)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 type Relation struct {
 	Schema TableSchema
 	Rows   [][]Value
@@ -21,3 +23,30 @@ func (r *Relation) Insert(row []Value) error {
 	r.Rows = append(r.Rows, row)
 	return nil
 }
+
+// Update replaces the row at index i, the new row must match the schema
+func (r *Relation) Update(i int, row []Value) error {
+	if i < 0 || i >= len(r.Rows) {
+		return fmt.Errorf("row %d out of range", i)
+	}
+	if err := r.Schema.Check(row); err != nil {
+		return err
+	}
+	r.Rows[i] = row
+	return nil
+}
+
+// Delete removes the rows at the given indexes and keeps the order of the remaining rows
+func (r *Relation) Delete(indexes []int) {
+	removed := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		removed[i] = true
+	}
+	rows := make([][]Value, 0, len(r.Rows))
+	for i, row := range r.Rows {
+		if !removed[i] {
+			rows = append(rows, row)
+		}
+	}
+	r.Rows = rows
+}
@@ -33,7 +33,8 @@ func (s *TableSchema) Check(row []Value) error {
 func (s *TableSchema) Prefix(name string) TableSchema {
 	var columns []ColumnSchema
 	for _, column := range s.Columns {
-		columns = append(columns, ColumnSchema{Name: fmt.Sprintf("%s.%s", name, column.Name), Type: column.Type})
+		column.Name = fmt.Sprintf("%s.%s", name, column.Name)
+		columns = append(columns, column)
 	}
 	return TableSchema{Columns: columns}
 }
@@ -47,11 +48,22 @@ func (s *TableSchema) String() string {
 }
 
 type ColumnSchema struct {
-	Name string
-	Type Type
+	Name     string
+	Type     Type
+	Nullable bool
+	// Default is stored when an INSERT omits the column, nil means NULL
+	Default Value
 }
 
 func (c *ColumnSchema) Check(value Value) error {
+	if IsNull(value) {
+		if !c.Nullable {
+			return fmt.Errorf("column %s does not allow NULL values", c.Name)
+		}
+		if value.Type() == TypeNull {
+			return nil
+		}
+	}
 	if c.Type != value.Type() {
 		return fmt.Errorf("mismatched types: column %s is of type %v, got %v", c.Name, c.Type, value.Type())
 	}
@@ -14,7 +14,14 @@ func (t Text) Type() Type {
 	return TypeText
 }
 
+func (t Text) Value() string {
+	return t.value
+}
+
 func (t Text) Compare(next Value) Comparison {
+	if IsNull(next) {
+		return ComparisonUnknown
+	}
 	nextText, ok := next.(Text)
 	if !ok {
 		return ComparisonIncomparable
@@ -28,6 +35,10 @@ func (t Text) Compare(next Value) Comparison {
 	return ComparisonEqual
 }
 
+func (t Text) Hash() uint64 {
+	return hashOf(TypeText, []byte(t.value))
+}
+
 func (t Text) String() string {
 	return fmt.Sprintf("%q", t.value)
 }
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
 type Type int
 
 const (
@@ -7,6 +13,8 @@ const (
 	TypeText
 	TypeBoolean
 	TypeDecimal
+	// TypeNull is the type of an untyped NULL literal, before it is coerced to the type it is used with
+	TypeNull
 )
 
 type Comparison int
@@ -16,12 +24,62 @@ const (
 	ComparisonEqual        Comparison = 0
 	ComparisonGreater      Comparison = 1
 	ComparisonIncomparable Comparison = 2
+	// ComparisonUnknown is the result of comparing with NULL, which is neither equal nor unequal to anything
+	ComparisonUnknown Comparison = 3
 )
 
 type Value interface {
 	Compare(next Value) Comparison
 	Type() Type
 	String() string
+	// Hash returns the same hash for any two values that Compare as equal
+	Hash() uint64
+}
+
+// hashOf hashes the type of a value together with its canonical encoding
+func hashOf(t Type, data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte{byte(t)})
+	h.Write(data)
+	return h.Sum64()
+}
+
+// Comparable reports whether values of the type have a total order, so that Compare between two
+// non-NULL values of the type never returns ComparisonIncomparable
+func (t Type) Comparable() bool {
+	switch t {
+	case TypeDate, TypeText, TypeBoolean, TypeDecimal, TypeNull:
+		return true
+	}
+	return false
+}
+
+// typeNames maps the type names accepted in column definitions, including common aliases, onto types
+var typeNames = map[string]Type{
+	"date":      TypeDate,
+	"text":      TypeText,
+	"varchar":   TypeText,
+	"char":      TypeText,
+	"character": TypeText,
+	"string":    TypeText,
+	"boolean":   TypeBoolean,
+	"bool":      TypeBoolean,
+	"decimal":   TypeDecimal,
+	"numeric":   TypeDecimal,
+	"number":    TypeDecimal,
+	"int":       TypeDecimal,
+	"integer":   TypeDecimal,
+	"bigint":    TypeDecimal,
+	"smallint":  TypeDecimal,
+}
+
+// ParseType returns the type with the given name, ignoring case
+func ParseType(name string) (Type, error) {
+	t, ok := typeNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown type %s", name)
+	}
+	return t, nil
 }
 
 func (t Type) String() string {
@@ -34,6 +92,8 @@ func (t Type) String() string {
 		return "boolean"
 	case TypeDecimal:
 		return "decimal"
+	case TypeNull:
+		return "null"
 	}
 	return "unknown"
 }
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestParseType(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    Type
+		wantErr bool
+	}{
+		{input: "date", want: TypeDate},
+		{input: "TEXT", want: TypeText},
+		{input: "varchar", want: TypeText},
+		{input: "Boolean", want: TypeBoolean},
+		{input: "decimal", want: TypeDecimal},
+		{input: "numeric", want: TypeDecimal},
+		{input: "integer", want: TypeDecimal},
+		{input: "null", wantErr: true},
+		{input: "blob", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseType(c.input)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseType(%q) error = %v, wantErr %v", c.input, err, c.wantErr)
+			continue
+		}
+		if !c.wantErr && got != c.want {
+			t.Errorf("ParseType(%q) == %v, want %v", c.input, got, c.want)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	cases := []struct {
+		a, b Value
+	}{
+		{a: NewDecimal("1"), b: NewDecimal("1.0")},
+		{a: NewDecimal("-2.50"), b: NewDecimal("-2.5")},
+		{a: NewDecimal("0.0"), b: NewDecimal("-0").Neg()},
+		{a: NewText("a"), b: NewText("a")},
+		{a: NewBoolean(true), b: NewBoolean(true)},
+		{a: NewDate(2024, 2, 29), b: NewDate(2024, 2, 29)},
+	}
+
+	for _, c := range cases {
+		if c.a.Compare(c.b) != ComparisonEqual {
+			t.Fatalf("%v.Compare(%v) = %v, want equal", c.a, c.b, c.a.Compare(c.b))
+		}
+		if c.a.Hash() != c.b.Hash() {
+			t.Errorf("%v.Hash() = %d, %v.Hash() = %d, want equal hashes", c.a, c.a.Hash(), c.b, c.b.Hash())
+		}
+	}
+
+	if NewDecimal("1").Hash() == NewDecimal("10").Hash() {
+		t.Errorf("1 and 10 have the same hash")
+	}
+	if NewText("1").Hash() == NewDecimal("1").Hash() {
+		t.Errorf("text and decimal 1 have the same hash")
+	}
+}